@@ -0,0 +1,208 @@
+package promptui
+
+import (
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/karantin2020/readline"
+)
+
+// Choice is a single key answer of ChoicePrompt
+type Choice struct {
+	// Key is the answer key, matched case-insensitive
+	Key rune
+	// Help describes the answer, it is shown when the help key is pressed,
+	// or in the key bindings line if KeyHelp is set
+	Help string
+}
+
+// ChoicePrompt represents a prompt with any number of single key answers,
+// like git's `[y,n,q,a,d,e,?]`. Default is the key used when
+// the input is empty.
+type ChoicePrompt struct {
+	BasicPrompt
+
+	// Choices are the answers to choose from
+	Choices []Choice
+
+	picked rune
+}
+
+// Run runs the prompt, returning the chosen key as it is defined in Choices
+func (chp *ChoicePrompt) Run() (rune, error) {
+	if len(chp.Choices) == 0 {
+		return 0, ErrorIncorrect
+	}
+	var def rune
+	if chp.Default != "" {
+		def = chp.find([]rune(chp.Default)[0])
+		if def == 0 {
+			return 0, ErrorIncorrect
+		}
+	}
+	err := chp.Init()
+	if err != nil {
+		return 0, err
+	}
+	chp.picked = 0
+	// actions are handled before choices, their keys can't be chosen
+	for _, c := range chp.Choices {
+		for _, a := range []Action{ActionSubmit, ActionCancel, ActionHelp} {
+			if chp.KeyMap.Is(unicode.ToLower(c.Key), a) || chp.KeyMap.Is(unicode.ToUpper(c.Key), a) {
+				return 0, ErrorIncorrect
+			}
+		}
+	}
+
+	chp.punctuation = "?"
+	chp.suggestedAnswer = " " + faint("["+chp.answers(def)+"]")
+	chp.prompt = chp.LabelInitial(chp.Label) + chp.suggestedAnswer + chp.punctuation + " "
+	bindings := make([]binding, 0, len(chp.Choices)+2)
+	for _, c := range chp.Choices {
+		bindings = append(bindings, binding{keys: []string{string(unicode.ToLower(c.Key))}, desc: c.Help})
+	}
+	bindings = append(bindings,
+		binding{actions: []Action{ActionSubmit}, desc: "submit"},
+		binding{actions: []Action{ActionCancel}, desc: "cancel"})
+	promptLine := func() string {
+		return withFooter(chp.Indent+chp.state+" "+chp.PromptInitial(chp.prompt), chp.keyHelp(bindings...))
+	}
+	chp.c.Prompt = promptLine()
+
+	chp.rl, err = readline.NewEx(chp.c)
+	if err != nil {
+		return 0, err
+	}
+	defer chp.rl.Close()
+
+	chp.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		if chp.toggleHelp(r, true) {
+			chp.rl.SetPrompt(promptLine())
+			return r, false
+		}
+		if chp.KeyMap.Is(r, ActionHelp) {
+			chp.rl.Write([]byte(chp.help()))
+			return r, false
+		}
+		r, ok := chp.KeyMap.resolve(r, ActionSubmit, ActionCancel)
+		if !ok {
+			return r, false
+		}
+		switch r {
+		case readline.CharInterrupt, readline.CharDelete,
+			readline.CharBackspace, readline.CharCtrlH:
+			return r, true
+		case readline.CharEnter:
+			return r, chp.picked != 0 || def != 0
+		}
+		return r, chp.find(r) != 0
+	}
+	chp.c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		if key == readline.CharEnter {
+			return nil, 0, false
+		}
+		prev := chp.picked
+		chp.picked = 0
+		chp.state = chp.IconInitial
+		if len(line) > 0 {
+			chp.picked = chp.find(line[len(line)-1])
+			chp.state = chp.IconGood
+		}
+		if key != 0 && chp.picked != prev {
+			input := ""
+			if chp.picked != 0 {
+				input = string(chp.picked)
+			}
+			chp.changed(input)
+		}
+		chp.rl.SetPrompt(promptLine())
+		if len(line) > 1 {
+			return line[len(line)-1:], 1, true
+		}
+		return nil, 0, false
+	})
+
+	_, err = chp.rl.Readline()
+	if err != nil {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
+			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
+		}
+		chp.rl.Write([]byte("\n"))
+		return 0, chp.canceled(err)
+	}
+
+	key := chp.picked
+	if key == 0 {
+		key = def
+	}
+	chp.state = chp.IconGood
+	chp.out = chp.Formatter(string(key))
+	chp.submitted(chp.out)
+	chp.rl.Write([]byte(chp.Indent + chp.state + " " + chp.prompt + chp.InputResult(chp.out) + "\n"))
+	return key, nil
+}
+
+// find returns the Choices key matching r, 0 if there is no such key
+func (chp *ChoicePrompt) find(r rune) rune {
+	for _, c := range chp.Choices {
+		if unicode.ToLower(c.Key) == unicode.ToLower(r) {
+			return c.Key
+		}
+	}
+	return 0
+}
+
+// answers returns the answers hint, the default key is upper-cased
+func (chp *ChoicePrompt) answers(def rune) string {
+	keys := make([]string, 0, len(chp.Choices)+1)
+	for _, c := range chp.Choices {
+		k := unicode.ToLower(c.Key)
+		if c.Key == def {
+			k = unicode.ToUpper(c.Key)
+		}
+		keys = append(keys, string(k))
+	}
+	keys = append(keys, chp.helpKeys()...)
+	return strings.Join(keys, ",")
+}
+
+// help returns the choices description
+func (chp *ChoicePrompt) help() string {
+	var b strings.Builder
+	for _, c := range chp.Choices {
+		b.WriteString(chp.Indent + string(unicode.ToLower(c.Key)) + " - " + c.Help + "\n")
+	}
+	if keys := chp.helpKeys(); len(keys) > 0 {
+		b.WriteString(chp.Indent + strings.Join(keys, "/") + " - print help\n")
+	}
+	return b.String()
+}
+
+// helpKeys returns names of the keys bound to ActionHelp
+func (chp *ChoicePrompt) helpKeys() []string {
+	var keys []string
+	for _, k := range chp.KeyMap[ActionHelp] {
+		keys = append(keys, keyName(k))
+	}
+	return keys
+}
+
+// Choose func is predefined easy choice prompt
+func Choose(label, answer string, choices []Choice) (rune, error) {
+	chp := ChoicePrompt{
+		BasicPrompt: BasicPrompt{
+			Label:   label,
+			Default: answer,
+		},
+		Choices: choices,
+	}
+	return chp.Run()
+}
@@ -0,0 +1,97 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestChoicePrompt(t *testing.T) {
+	chp := ChoicePrompt{
+		Choices: []Choice{
+			{Key: 'y', Help: "stage this hunk"},
+			{Key: 'n', Help: "do not stage this hunk"},
+			{Key: 'Q', Help: "quit"},
+		},
+	}
+	chp.KeyMap = EmacsKeyMap()
+
+	t.Run("matches keys case-insensitive", func(t *testing.T) {
+		if k := chp.find('q'); k != 'Q' {
+			t.Errorf("wrong key: %q != %q", k, 'Q')
+		}
+		if k := chp.find('Y'); k != 'y' {
+			t.Errorf("wrong key: %q != %q", k, 'y')
+		}
+		if k := chp.find('x'); k != 0 {
+			t.Errorf("unexpected key: %q", k)
+		}
+	})
+
+	t.Run("upper-cases the default answer", func(t *testing.T) {
+		expected := "y,N,q,?"
+		if a := chp.answers('n'); a != expected {
+			t.Errorf("wrong answers: %s != %s", a, expected)
+		}
+	})
+
+	t.Run("shows rebound help key", func(t *testing.T) {
+		km := chp.KeyMap
+		defer func() { chp.KeyMap = km }()
+		chp.KeyMap = km.Override(KeyMap{ActionHelp: {'h'}})
+		expected := "y,N,q,h"
+		if a := chp.answers('n'); a != expected {
+			t.Errorf("wrong answers: %s != %s", a, expected)
+		}
+		if h := chp.help(); !strings.HasSuffix(h, "h - print help\n") {
+			t.Errorf("wrong help: %q", h)
+		}
+	})
+
+	t.Run("rejects keys bound to actions", func(t *testing.T) {
+		c := ChoicePrompt{
+			BasicPrompt: BasicPrompt{
+				KeyMap: EmacsKeyMap().Override(KeyMap{ActionHelp: {'n'}}),
+				stdout: &bytes.Buffer{},
+			},
+			Choices: chp.Choices,
+		}
+		if _, err := c.Run(); err != ErrorIncorrect {
+			t.Errorf("wrong error: %v != %v", err, ErrorIncorrect)
+		}
+	})
+}
+
+func TestChoicePromptEOF(t *testing.T) {
+	chp := ChoicePrompt{
+		BasicPrompt: BasicPrompt{
+			stdin:  &bytes.Buffer{},
+			stdout: &bytes.Buffer{},
+		},
+		Choices: []Choice{{Key: 'y', Help: "yes"}},
+	}
+	if _, err := chp.Run(); err != ErrEOF {
+		t.Errorf("wrong error: %v != %v", err, ErrEOF)
+	}
+}
+
+func TestChoicePromptRerun(t *testing.T) {
+	in := bytes.Buffer{}
+	chp := ChoicePrompt{
+		BasicPrompt: BasicPrompt{
+			stdin:  &in,
+			stdout: &bytes.Buffer{},
+		},
+		Choices: []Choice{{Key: 'y', Help: "yes"}, {Key: 'n', Help: "no"}},
+	}
+
+	in.Write([]byte("y\n"))
+	if k, err := chp.Run(); err != nil || k != 'y' {
+		t.Fatalf("wrong result: %q %v", k, err)
+	}
+	// the previous answer is not submitted on Enter
+	in.Write([]byte("\n"))
+	if k, err := chp.Run(); err != ErrEOF {
+		t.Errorf("wrong result: %q %v != %v", k, err, ErrEOF)
+	}
+}
@@ -20,6 +20,9 @@ const (
 	FGUnderline
 )
 
+// reverse swaps foreground and background colors
+const reverse attribute = 7
+
 // Forground color attributes
 const (
 	FGBlack attribute = iota + 30
@@ -39,6 +42,7 @@ var (
 	hideCursor = esc + "?25l"
 	showCursor = esc + "?25h"
 	clearLine  = esc + "2K"
+	clearDown  = esc + "J"
 )
 
 func upLine(n uint) string {
@@ -64,6 +68,11 @@ func downLine(n uint) string {
 	return movementCode(n, 'B')
 }
 
+// backward moves cursor n columns left
+func backward(n uint) string {
+	return movementCode(n, 'D')
+}
+
 func movementCode(n uint, code rune) string {
 	return esc + strconv.FormatUint(uint64(n), 10) + string(code)
 }
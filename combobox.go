@@ -0,0 +1,240 @@
+package promptui
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/karantin2020/readline"
+)
+
+// ComboBox represents a text input with the list of items filtered by the
+// input under it. Enter picks the highlighted item or creates a new value
+// from the input, all in one screen.
+type ComboBox struct {
+	BasicPrompt
+
+	// Items are the items to filter
+	Items []string
+
+	// AddLabel is shown before the input in the row that creates a new value,
+	// "Create" by default
+	AddLabel string
+
+	// Size is the max number of visible rows, 5 by default
+	Size int
+
+	// Filter reports if the item matches the input,
+	// case-insensitive substring match by default
+	Filter func(input, item string) bool
+
+	input    *textArea
+	rows     []int // indexes of matched Items, SelectedAdd for the add row
+	selected int   // index in rows
+	start    int
+	screen   *screen
+	errMsg   string
+}
+
+// Run runs the combobox. It returns the index of the picked item and its
+// value. If a new value is created, SelectedAdd is returned as the index.
+// New values are checked with Validate.
+func (cb *ComboBox) Run() (int, string, error) {
+	err := cb.Init()
+	if err != nil {
+		return 0, "", err
+	}
+	if cb.Size <= 0 {
+		cb.Size = 5
+	}
+	if cb.AddLabel == "" {
+		cb.AddLabel = "Create"
+	}
+	if cb.Filter == nil {
+		cb.Filter = func(input, item string) bool {
+			return strings.Contains(strings.ToLower(item), strings.ToLower(input))
+		}
+	}
+
+	cb.c.VimMode = false
+	cb.input = newTextArea(cb.Default, 1)
+	cb.screen = &screen{w: cb.c.Stdout}
+	cb.update()
+	cb.c.Prompt = cb.footer()
+
+	cb.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		if cb.toggleHelp(r, cb.input.String() == "") {
+			cb.rl.SetPrompt(cb.footer())
+			return r, false
+		}
+		r, ok := cb.KeyMap.resolve(r, ActionSubmit, ActionCancel, ActionNext, ActionPrev)
+		if !ok {
+			return r, false
+		}
+		switch r {
+		case readline.CharInterrupt:
+			return r, true
+		case readline.CharEnter, readline.CharCtrlJ:
+			// the input is one line, pasted line breaks submit it too
+			if len(cb.rows) > 0 && cb.errMsg == "" {
+				return readline.CharEnter, true
+			}
+			return r, false
+		case readline.CharPrev:
+			if cb.selected > 0 {
+				cb.selected--
+			}
+		case readline.CharNext:
+			if cb.selected < len(cb.rows)-1 {
+				cb.selected++
+			}
+		case readline.CharTab:
+			if len(cb.rows) == 0 || cb.rows[cb.selected] == SelectedAdd {
+				return r, false
+			}
+			cb.input = newTextArea(cb.Items[cb.rows[cb.selected]], 1)
+			cb.update()
+			cb.changed(cb.input.String())
+		default:
+			prev := cb.input.String()
+			if !cb.input.handle(r) {
+				return r, false
+			}
+			if input := cb.input.String(); input != prev {
+				cb.update()
+				cb.changed(input)
+			}
+		}
+		cb.validate()
+		cb.draw()
+		cb.rl.SetPrompt(cb.footer())
+		return r, false
+	}
+
+	cb.rl, err = readline.NewEx(cb.c)
+	if err != nil {
+		return 0, "", err
+	}
+	defer cb.rl.Close()
+
+	cb.rl.Write([]byte(hideCursor))
+	cb.validate()
+	cb.draw()
+	_, err = cb.rl.Readline()
+	cb.screen.clear()
+	cb.rl.Write([]byte(showCursor))
+
+	if err != nil {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
+			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
+		}
+		return 0, "", cb.canceled(err)
+	}
+
+	idx := cb.rows[cb.selected]
+	cb.out = cb.input.String()
+	if idx != SelectedAdd {
+		cb.out = cb.Items[idx]
+	}
+	cb.out = cb.Formatter(cb.out)
+	cb.submitted(cb.out)
+	cb.state = cb.IconGood
+	cb.rl.Write([]byte(cb.Indent + cb.state + " " + cb.prompt + cb.InputResult(cb.out) + "\n"))
+	return idx, cb.out, nil
+}
+
+// update filters items by the input and highlights the first matched item
+func (cb *ComboBox) update() {
+	input := cb.input.String()
+	cb.rows = cb.rows[:0]
+	exact := false
+	for i, item := range cb.Items {
+		if input == "" || cb.Filter(input, item) {
+			cb.rows = append(cb.rows, i)
+			exact = exact || item == input
+		}
+	}
+	cb.selected = 0
+	cb.start = 0
+	if input != "" && !exact {
+		cb.rows = append([]int{SelectedAdd}, cb.rows...)
+		if len(cb.rows) > 1 {
+			cb.selected = 1
+		}
+	}
+}
+
+// validate checks the new value if the add row is highlighted
+func (cb *ComboBox) validate() {
+	cb.errMsg = ""
+	switch {
+	case len(cb.rows) == 0:
+		cb.state = cb.IconInitial
+	case cb.rows[cb.selected] != SelectedAdd:
+		cb.state = cb.IconGood
+	default:
+		cb.state = cb.IconGood
+		err := cb.validFn(cb.input.String())
+		cb.validated(cb.input.String(), err)
+		if err != nil {
+			cb.errMsg = err.Error()
+			cb.state = cb.IconBad
+		}
+	}
+}
+
+// draw redraws the input line and visible rows
+func (cb *ComboBox) draw() {
+	if cb.selected < cb.start {
+		cb.start = cb.selected
+	}
+	if cb.selected >= cb.start+cb.Size {
+		cb.start = cb.selected - cb.Size + 1
+	}
+	end := cb.start + cb.Size
+	if end > len(cb.rows) {
+		end = len(cb.rows)
+	}
+
+	lines := []string{cb.Indent + cb.state + " " + cb.PromptInitial(cb.prompt) +
+		cursorLine(cb.input.lines[0], cb.input.col, cb.InputInitial)}
+	for i := cb.start; i < end; i++ {
+		item := ""
+		if cb.rows[i] == SelectedAdd {
+			item = cb.AddLabel + " " + strconv.Quote(cb.input.String())
+		} else {
+			item = cb.Items[cb.rows[i]]
+		}
+		selection := " "
+		if i == cb.selected {
+			selection = IconQuest
+			item = blue(item)
+		}
+		lines = append(lines, cb.Indent+"  "+selection+" "+item)
+	}
+	cb.screen.draw(lines)
+}
+
+// footer returns the line under the list with validation error or key hints.
+// All key bindings are listed if KeyHelp is set
+func (cb *ComboBox) footer() string {
+	if cb.errMsg != "" {
+		return cb.Indent + "  " + red("Error: ") + cb.errMsg
+	}
+	bindings := []binding{
+		{actions: []Action{ActionPrev, ActionNext}, desc: "move"},
+		{keys: []string{"Tab"}, desc: "complete"},
+		{actions: []Action{ActionSubmit}, desc: "pick"},
+	}
+	if cb.KeyHelp {
+		return cb.keyHelp(append(bindings, binding{actions: []Action{ActionCancel}, desc: "cancel"})...)
+	}
+	return cb.Indent + "  " + faint(cb.KeyMap.help(bindings...))
+}
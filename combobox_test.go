@@ -0,0 +1,76 @@
+package promptui
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestComboBoxFilter(t *testing.T) {
+	cb := ComboBox{
+		Items: []string{"eu-west-1", "eu-central-1", "us-east-1"},
+		Filter: func(input, item string) bool {
+			return len(item) >= len(input) && item[:len(input)] == input
+		},
+	}
+
+	t.Run("shows add row and highlights first match", func(t *testing.T) {
+		cb.input = newTextArea("eu", 1)
+		cb.update()
+		if expected := []int{SelectedAdd, 0, 1}; !reflect.DeepEqual(cb.rows, expected) {
+			t.Errorf("wrong rows: %v != %v", cb.rows, expected)
+		}
+		if cb.selected != 1 {
+			t.Errorf("wrong selection: %d", cb.selected)
+		}
+	})
+
+	t.Run("hides add row on exact match", func(t *testing.T) {
+		cb.input = newTextArea("us-east-1", 1)
+		cb.update()
+		if expected := []int{2}; !reflect.DeepEqual(cb.rows, expected) {
+			t.Errorf("wrong rows: %v != %v", cb.rows, expected)
+		}
+	})
+
+	t.Run("highlights add row without matches", func(t *testing.T) {
+		cb.input = newTextArea("ap", 1)
+		cb.update()
+		if expected := []int{SelectedAdd}; !reflect.DeepEqual(cb.rows, expected) || cb.selected != 0 {
+			t.Errorf("wrong rows: %v != %v", cb.rows, expected)
+		}
+	})
+}
+
+func TestComboBoxNewline(t *testing.T) {
+	in := bytes.Buffer{}
+	cb := ComboBox{
+		BasicPrompt: BasicPrompt{
+			stdin:  &in,
+			stdout: &bytes.Buffer{},
+		},
+		Items: []string{"eu-west-1"},
+	}
+
+	in.Write([]byte("ab\n"))
+	i, res, err := cb.Run()
+	if err != nil {
+		t.Fatalf("error during prompt: %s", err)
+	}
+	if i != SelectedAdd || res != "ab" {
+		t.Errorf("wrong result: %d %q != %d %q", i, res, SelectedAdd, "ab")
+	}
+}
+
+func TestComboBoxEOF(t *testing.T) {
+	cb := ComboBox{
+		BasicPrompt: BasicPrompt{
+			stdin:  &bytes.Buffer{},
+			stdout: &bytes.Buffer{},
+		},
+		Items: []string{"eu-west-1"},
+	}
+	if _, _, err := cb.Run(); err != ErrEOF {
+		t.Errorf("wrong error: %v != %v", err, ErrEOF)
+	}
+}
@@ -1,11 +1,8 @@
 package promptui
 
 import (
-	"bytes"
 	"errors"
 	"io"
-	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/karantin2020/readline"
@@ -16,6 +13,30 @@ var (
 	ErrorIncorrect = errors.New("Incorrect default values")
 )
 
+// ConfirmAnswer is the typed result of ConfirmPrompt
+type ConfirmAnswer int
+
+const (
+	// AnswerNo is returned when "n" is supplied
+	AnswerNo ConfirmAnswer = iota
+	// AnswerYes is returned when "y" is supplied
+	AnswerYes
+	// AnswerOpt is returned when ConfirmOpt is supplied
+	AnswerOpt
+)
+
+// String returns "Y", "N" or "OPT" for AnswerOpt. The prompt prints
+// the upper-cased ConfirmOpt key for AnswerOpt, it is returned by Run
+func (a ConfirmAnswer) String() string {
+	switch a {
+	case AnswerYes:
+		return "Y"
+	case AnswerOpt:
+		return "OPT"
+	}
+	return "N"
+}
+
 // ConfirmPrompt represents a single line text field input.
 type ConfirmPrompt struct {
 	BasicPrompt
@@ -23,7 +44,21 @@ type ConfirmPrompt struct {
 	// ConfirmOpt is the 3d answer option
 	ConfirmOpt string
 
+	// AbortOnNo makes Run, RunBool and RunAnswer return ErrAbort
+	// when "n" is supplied
+	AbortOnNo bool
+
+	// Immediate commits the answer as soon as a valid answer key is pressed,
+	// without waiting for Enter
+	Immediate bool
+
+	// Handlers are called in order for every key before it is checked
+	// to be an answer key
+	Handlers []KeyHandler
+
 	confirmDefault string
+	pressed        rune
+	answer         ConfirmAnswer
 }
 
 // Run func implements confirn prompt
@@ -41,8 +76,7 @@ func (cp *ConfirmPrompt) Run() (string, error) {
 	}
 
 	cp.confirmDefault = strings.ToUpper(cp.Default)
-
-	cp.c.Stdin = ioutil.NopCloser(io.MultiReader(bytes.NewBuffer([]byte(cp.out)), os.Stdin))
+	cp.pressed = 0
 
 	cp.rl, err = readline.NewEx(cp.c)
 	if err != nil {
@@ -61,35 +95,129 @@ func (cp *ConfirmPrompt) Run() (string, error) {
 	// cp.confirmDefault = strings.ToUpper(cp.Default)
 	// cp.Default = ""
 	cp.prompt = cp.LabelInitial(cp.Label) + cp.punctuation + cp.suggestedAnswer + " "
-	// cp.out = cp.Default
-	// cp.c.Stdin = ioutil.NopCloser(io.MultiReader(bytes.NewBuffer([]byte(cp.out)), os.Stdin))
 
 	setupConfirm(cp.c, cp.prompt, cp, cp.rl)
 	cp.out, err = cp.rl.Readline()
+	if cp.pressed != 0 {
+		cp.out = string(cp.pressed)
+	}
 	if cp.out == "" {
 		cp.out = cp.confirmDefault
 	}
 	if err != nil {
-		if err.Error() == "Interrupt" {
+		switch {
+		case err.Error() == "Interrupt":
 			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
 		}
 		cp.rl.Write([]byte("\n"))
-		return "", err
+		return "", cp.canceled(err)
 	}
 	cp.out = strings.ToUpper(cp.out)
+	cp.answer = cp.parseAnswer(cp.out)
 	cp.state = cp.IconGood
 	cp.out = cp.Formatter(cp.out)
+	cp.submitted(cp.out)
 	separator := " "
 	if cp.NoIcons {
 		separator = ""
 	}
 	cp.rl.Write([]byte(cp.Indent + cp.state + separator + cp.prompt + cp.InputResult(cp.out) + "\n"))
+	if cp.AbortOnNo && cp.answer == AnswerNo {
+		return cp.out, ErrAbort
+	}
 	return cp.out, err
 }
 
+// RunAnswer runs the prompt, returning the typed answer
+func (cp *ConfirmPrompt) RunAnswer() (ConfirmAnswer, error) {
+	_, err := cp.Run()
+	if err != nil {
+		return AnswerNo, err
+	}
+	return cp.answer, nil
+}
+
+// RunBool runs the prompt, returning true if "y" is supplied.
+// ConfirmOpt answer is reported as false
+func (cp *ConfirmPrompt) RunBool() (bool, error) {
+	a, err := cp.RunAnswer()
+	return a == AnswerYes, err
+}
+
+func (cp *ConfirmPrompt) parseAnswer(s string) ConfirmAnswer {
+	switch s {
+	case "Y":
+		return AnswerYes
+	case "N", "":
+		return AnswerNo
+	}
+	if cp.ConfirmOpt != "" &&
+		[]rune(s)[0] == []rune(strings.ToUpper(cp.ConfirmOpt))[0] {
+		return AnswerOpt
+	}
+	return AnswerNo
+}
+
 func setupConfirm(c *readline.Config, prompt string,
 	cp *ConfirmPrompt, rl *readline.Instance) {
+	var separator = " "
+	if cp.NoIcons {
+		separator = ""
+	}
+	answers := []string{"y", "n"}
+	if cp.ConfirmOpt != "" {
+		answers = append(answers, string([]rune(cp.ConfirmOpt)[0]))
+	}
+	bindings := []binding{
+		{keys: answers, desc: "answer"},
+		{actions: []Action{ActionSubmit}, desc: "submit"},
+		{actions: []Action{ActionCancel}, desc: "cancel"},
+	}
+	state := cp.IconInitial
+	st := &PromptState{selected: -1}
+	setPrompt := func() {
+		footer := cp.keyHelp(bindings...)
+		if st.message != "" {
+			footer = cp.Indent + "  " + st.message
+		}
+		rl.SetPrompt(withFooter(cp.Indent+st.iconOr(state)+separator+cp.PromptInitial(prompt), footer))
+	}
+	setPrompt()
+	var cur []rune
+
 	filterInput := func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		if cp.pressed != 0 {
+			// the answer is committed, readline may still get keys
+			// typed ahead
+			return r, false
+		}
+		if cp.toggleHelp(r, true) {
+			setPrompt()
+			return r, false
+		}
+		r, ok := cp.KeyMap.resolve(r, ActionSubmit, ActionCancel)
+		if !ok {
+			return r, false
+		}
+		if len(cp.Handlers) > 0 {
+			var line []rune
+			var replaced bool
+			r, line, _, replaced = st.handle(cp.Handlers, cur, len(cur), r)
+			if replaced {
+				cur = line
+				rl.Operation.SetBuffer(string(line))
+			}
+			setPrompt()
+			if replaced && st.action == "" {
+				return r, false
+			}
+		}
 		switch r {
 		case readline.CharCtrlZ,
 			readline.CharInterrupt,
@@ -101,11 +229,19 @@ func setupConfirm(c *readline.Config, prompt string,
 			readline.CharBackspace:
 			break
 		case 'Y', 'y', 'N', 'n':
+			if cp.Immediate {
+				cp.pressed = r
+				return readline.CharEnter, true
+			}
 			break
 		default:
 			if len(cp.ConfirmOpt) > 0 {
 				if r == []rune(cp.ConfirmOpt)[0] ||
 					r == []rune(strings.ToUpper(cp.ConfirmOpt))[0] {
+					if cp.Immediate {
+						cp.pressed = r
+						return readline.CharEnter, true
+					}
 					break
 				}
 			}
@@ -125,12 +261,6 @@ func setupConfirm(c *readline.Config, prompt string,
 			return nil, 0, false
 		}
 
-		var state string
-		var separator = " "
-		if cp.NoIcons {
-			separator = ""
-		}
-
 		switch key {
 		case 'Y', 'y', 'N', 'n':
 			if len(line) > 1 {
@@ -147,7 +277,7 @@ func setupConfirm(c *readline.Config, prompt string,
 				}
 			}
 			state = cp.IconInitial
-			rl.SetPrompt(cp.Indent + state + separator + cp.PromptInitial(prompt))
+			setPrompt()
 			rl.Refresh()
 			return []rune(""), 0, true
 		}
@@ -158,12 +288,23 @@ func setupConfirm(c *readline.Config, prompt string,
 			state = cp.IconGood
 		}
 
-		rl.SetPrompt(cp.Indent + state + separator + cp.PromptInitial(prompt))
+		setPrompt()
 		rl.Refresh()
 
 		return nil, 0, false
 	}
-	c.SetListener(confirmReader)
+	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		newLine, newPos, ok := confirmReader(line, pos, key)
+		prev := string(cur)
+		cur = append([]rune(nil), line...)
+		if ok {
+			cur = append([]rune(nil), newLine...)
+		}
+		if key != 0 && string(cur) != prev {
+			cp.changed(string(cur))
+		}
+		return newLine, newPos, ok
+	})
 }
 
 // Confirm func is predefined easy confirm promp
@@ -177,3 +318,15 @@ func Confirm(label, answer string, noIcons bool) (string, error) {
 	}
 	return cp.Run()
 }
+
+// ConfirmBool func is predefined easy confirm prompt returning bool
+func ConfirmBool(label, answer string, noIcons bool) (bool, error) {
+	cp := ConfirmPrompt{
+		BasicPrompt: BasicPrompt{
+			Label:   label,
+			Default: answer,
+			NoIcons: noIcons,
+		},
+	}
+	return cp.RunBool()
+}
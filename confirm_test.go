@@ -0,0 +1,63 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirmAnswer(t *testing.T) {
+	cp := ConfirmPrompt{ConfirmOpt: "?"}
+	cases := []struct {
+		in   string
+		want ConfirmAnswer
+	}{
+		{"Y", AnswerYes},
+		{"N", AnswerNo},
+		{"", AnswerNo},
+		{"?", AnswerOpt},
+		{"X", AnswerNo},
+	}
+	for _, c := range cases {
+		if got := cp.parseAnswer(c.in); got != c.want {
+			t.Errorf("wrong answer for %q: %s != %s", c.in, got, c.want)
+		}
+	}
+}
+
+func immediateTest(input, def, output string) func(t *testing.T) {
+	return func(t *testing.T) {
+		in := bytes.Buffer{}
+		out := bytes.Buffer{}
+		cp := ConfirmPrompt{
+			BasicPrompt: BasicPrompt{
+				Label:   "test",
+				Default: def,
+				stdin:   &in,
+				stdout:  &out,
+			},
+			Immediate: true,
+		}
+
+		in.Write([]byte(input))
+		res, err := cp.Run()
+
+		if err != nil {
+			t.Errorf("error during prompt: %s", err)
+		}
+
+		if res != output {
+			t.Errorf("wrong result: %s != %s", res, output)
+		}
+
+		expected := "test? " + faint("["+strings.Replace("y/n", strings.ToLower(def), def, 1)+"]") + " \033[2m" + output + "\033[0m\n"
+		if !strings.HasSuffix(out.String(), expected) {
+			t.Errorf("wrong output: %q doesn't end with %q", out.String(), expected)
+		}
+	}
+}
+
+func TestConfirmImmediate(t *testing.T) {
+	t.Run("commits the answer key", immediateTest("yn", "N", "Y"))
+	t.Run("ignores other keys", immediateTest("xn", "Y", "N"))
+}
@@ -0,0 +1,65 @@
+package promptui
+
+import "fmt"
+
+// DangerConfirm represents a prompt that requires typing the expected phrase
+// to confirm a dangerous action, like GitHub's "type the repository name
+// to confirm".
+type DangerConfirm struct {
+	Prompt
+
+	// Phrase is the exact text user must type to confirm
+	Phrase string
+
+	// Attempts limits the number of wrong submits, 0 means unlimited
+	Attempts int
+}
+
+// Run runs the prompt. It returns nil if the phrase was typed,
+// ErrAbort if attempts are over. Validate is called after the phrase
+// is matched, Default is not used.
+func (dc *DangerConfirm) Run() error {
+	if dc.Phrase == "" {
+		return ErrorIncorrect
+	}
+	label, def, validate, attempts := dc.Label, dc.Default, dc.Validate, dc.maxAttempts
+	defer func() {
+		dc.Label, dc.Default, dc.Validate, dc.maxAttempts = label, def, validate, attempts
+	}()
+	if label == "" {
+		dc.Label = "Type " + bold(dc.Phrase) + " to confirm"
+	} else {
+		dc.Label = label + " (type " + bold(dc.Phrase) + " to confirm)"
+	}
+	// the default would type the phrase for the user
+	dc.Default = ""
+	dc.maxAttempts = dc.Attempts
+	dc.Validate = func(s string) error {
+		if s != dc.Phrase {
+			return NewValidationError(fmt.Sprintf("input does not match %q", dc.Phrase))
+		}
+		if validate != nil {
+			return validate(s)
+		}
+		return nil
+	}
+	_, err := dc.Prompt.Run()
+	if _, ok := err.(*ValidationError); ok {
+		return ErrAbort
+	}
+	return err
+}
+
+// ConfirmDanger func is predefined easy typed-phrase confirm prompt
+func ConfirmDanger(label, phrase string, attempts int) error {
+	dc := DangerConfirm{
+		Prompt: Prompt{
+			BasicPrompt: BasicPrompt{
+				Label: label,
+			},
+		},
+		Phrase:   phrase,
+		Attempts: attempts,
+	}
+	return dc.Run()
+}
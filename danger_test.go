@@ -0,0 +1,65 @@
+package promptui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func dangerTest(input string, attempts int, expected error) func(t *testing.T) {
+	return func(t *testing.T) {
+		in := bytes.Buffer{}
+		out := bytes.Buffer{}
+		dc := DangerConfirm{
+			Prompt: Prompt{
+				BasicPrompt: BasicPrompt{
+					Label:  "Delete",
+					stdin:  &in,
+					stdout: &out,
+				},
+			},
+			Phrase:   "repo",
+			Attempts: attempts,
+		}
+
+		in.Write([]byte(input))
+		if err := dc.Run(); err != expected {
+			t.Errorf("wrong error: %v != %v", err, expected)
+		}
+		if dc.Label != "Delete" || dc.Validate != nil || dc.maxAttempts != 0 {
+			t.Errorf("prompt fields are not restored")
+		}
+	}
+}
+
+func TestDangerConfirm(t *testing.T) {
+	t.Run("confirms the phrase", dangerTest("repo\n", 0, nil))
+	t.Run("aborts after attempts", dangerTest("rep\nrepo!\n", 2, ErrAbort))
+	t.Run("can be interrupted", dangerTest("re\x03", 0, ErrInterrupt))
+}
+
+func TestDangerConfirmValidate(t *testing.T) {
+	in := bytes.Buffer{}
+	dc := DangerConfirm{
+		Prompt: Prompt{
+			BasicPrompt: BasicPrompt{
+				Label:   "Delete",
+				Default: "repo",
+				Validate: func(s string) error {
+					return NewValidationError("never")
+				},
+				stdin:  &in,
+				stdout: &bytes.Buffer{},
+			},
+		},
+		Phrase:   "repo",
+		Attempts: 1,
+	}
+
+	in.Write([]byte("repo\n"))
+	if err := dc.Run(); err != ErrAbort {
+		t.Errorf("wrong error: %v != %v", err, ErrAbort)
+	}
+	if dc.Default != "repo" || dc.Validate == nil {
+		t.Errorf("prompt fields are not restored")
+	}
+}
@@ -3,10 +3,15 @@ package promptui
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+
+	"github.com/karantin2020/readline"
 )
 
 var (
@@ -18,19 +23,70 @@ var (
 	ErrorRead = errors.New("in promptui:Editor: Error read temp file")
 	// ErrorClose describes close editor error
 	ErrorClose = errors.New("in promptui:Editor: Error close temp file")
+	// ErrorCommand describes editor command line parse error
+	ErrorCommand = errors.New("in promptui:Editor: Error parse editor command")
 )
 
 var (
 	bom = []byte{0xef, 0xbb, 0xbf}
 )
 
+// EditorConfig configures the editor run
+type EditorConfig struct {
+	// Editor is the editor command line, it may contain arguments
+	// quoted like in shell, e.g. `code --wait`.
+	// $VISUAL or $EDITOR is used if empty
+	Editor string
+
+	// Pattern is the temp file name pattern as in ioutil.TempFile,
+	// "promptui.*" + Ext by default
+	Pattern string
+
+	// Ext is the temp file extension, e.g. ".yaml", ".txt" by default.
+	// Ext is ignored if Pattern is set
+	Ext string
+
+	// Comment is the comment line marker. If set, lines starting with
+	// Comment are stripped on read, like git commit message lines
+	// starting with "#"
+	Comment string
+
+	// Help is written to the top of the file, each line prefixed with
+	// Comment, "#" is used if Comment is empty
+	Help string
+
+	// AbortEmpty makes Edit return ErrAbort if the result is empty
+	// after comments are stripped
+	AbortEmpty bool
+}
+
 // Editor func opens default editor to edit `s string`.
 // Returns edit result. Creates temp file to edit string
 func Editor(editor, s string) (string, error) {
+	ec := EditorConfig{Editor: editor}
+	return ec.Edit(s)
+}
+
+// Edit opens the editor to edit `s string`.
+// Returns edit result. Creates temp file to edit string
+func (ec *EditorConfig) Edit(s string) (string, error) {
+	editor := ec.Editor
 	if editor == "" {
 		editor = getEditor()
 	}
-	tf, err := ioutil.TempFile("", "promptui.*.txt")
+	args, err := splitCommand(editor)
+	if err != nil {
+		return "", err
+	}
+	pattern := ec.Pattern
+	if pattern == "" {
+		ext := ec.Ext
+		if ext == "" {
+			ext = ".txt"
+		}
+		pattern = "promptui.*" + ext
+	}
+	tf, err := ioutil.TempFile("", pattern)
 	if err != nil {
 		return "", ErrorOpen
 	}
@@ -48,6 +104,18 @@ func Editor(editor, s string) (string, error) {
 		}
 	}
 
+	comment := ec.Comment
+	if comment == "" && ec.Help != "" {
+		comment = "#"
+	}
+	if ec.Help != "" {
+		for _, l := range strings.Split(strings.TrimRight(ec.Help, "\n"), "\n") {
+			if _, err := tf.WriteString(comment + " " + l + "\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+
 	// write initial value
 	if _, err := tf.WriteString(s); err != nil {
 		return "", err
@@ -58,7 +126,7 @@ func Editor(editor, s string) (string, error) {
 		return "", err
 	}
 
-	args := []string{editor, tf.Name()}
+	args = append(args, tf.Name())
 
 	// open the editor
 	cmd := exec.Command(args[0], args[1:]...)
@@ -83,9 +151,82 @@ func Editor(editor, s string) (string, error) {
 		text = string(raw)
 	}
 
+	if comment != "" {
+		text = stripComments(text, comment)
+	}
+	if ec.AbortEmpty && strings.TrimSpace(text) == "" {
+		return "", ErrAbort
+	}
+
 	return text, nil
 }
 
+// stripComments removes lines starting with comment marker and
+// surrounding empty lines
+func stripComments(s, comment string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, l := range lines {
+		if strings.HasPrefix(l, comment) {
+			continue
+		}
+		out = append(out, l)
+	}
+	return strings.Trim(strings.Join(out, "\n"), "\r\n")
+}
+
+// splitCommand splits command line into arguments. Single and double quotes
+// group words, backslash escapes the next rune outside of single quotes.
+// Backslash is kept as is on windows, where it is the path separator
+func splitCommand(s string) ([]string, error) {
+	var (
+		args    []string
+		arg     []rune
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	escapes := runtime.GOOS != "windows"
+	for _, r := range s {
+		switch {
+		case escaped:
+			arg = append(arg, r)
+			escaped = false
+		case r == '\\' && escapes && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg = append(arg, r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, string(arg))
+				arg = arg[:0]
+				inArg = false
+			}
+		default:
+			arg = append(arg, r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, ErrorCommand
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	if len(args) == 0 {
+		return nil, ErrorCommand
+	}
+	return args, nil
+}
+
 func getEditor() string {
 	if runtime.GOOS == "windows" {
 		return "notepad"
@@ -100,3 +241,216 @@ func getEditor() string {
 	}
 	return "vim"
 }
+
+// EditorPrompt represents a prompt that edits its value in external editor.
+// It shows a truncated preview of the value, opens the editor on Enter,
+// validates the result and offers to edit again, accept or cancel.
+type EditorPrompt struct {
+	BasicPrompt
+
+	// Editor is the editor to use, $VISUAL or $EDITOR is used if empty
+	Editor string
+
+	// Ext is the temp file extension, e.g. ".yaml", ".txt" by default
+	Ext string
+
+	// EditorHelp is shown as comment lines in editor and stripped on read
+	EditorHelp string
+
+	// AbortEmpty makes Run return ErrAbort if the edited value is empty
+	AbortEmpty bool
+
+	// PreviewLines is the max number of value lines in preview, 3 by default
+	PreviewLines int
+
+	// PreviewWidth is the max number of runes in preview line, 60 by default
+	PreviewWidth int
+}
+
+// Run runs the prompt, returning the validated input.
+// ErrAbort is returned if user cancels the edit.
+func (ep *EditorPrompt) Run() (string, error) {
+	err := ep.Init()
+	if err != nil {
+		return "", err
+	}
+	if ep.PreviewLines <= 0 {
+		ep.PreviewLines = 3
+	}
+	if ep.PreviewWidth <= 0 {
+		ep.PreviewWidth = 60
+	}
+	ep.prompt = ep.LabelInitial(ep.Label) + ep.punctuation + " "
+	ep.out = ep.Default
+
+	open := binding{actions: []Action{ActionSubmit}, desc: "open editor"}
+	footer := func() string {
+		if ep.showHelp {
+			return ep.keyHelp(open, binding{actions: []Action{ActionCancel}, desc: "cancel"})
+		}
+		return ep.Indent + "  " + faint("Press "+ep.KeyMap.help(open))
+	}
+	ep.c.Prompt = footer()
+	ep.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		if ep.toggleHelp(r, true) {
+			ep.rl.SetPrompt(footer())
+			return r, false
+		}
+		r, ok := ep.KeyMap.resolve(r, ActionSubmit, ActionCancel)
+		if !ok {
+			return r, false
+		}
+		switch r {
+		case readline.CharEnter, readline.CharInterrupt, readline.CharDelete:
+			return r, true
+		}
+		return r, false
+	}
+	ep.rl, err = readline.NewEx(ep.c)
+	if err != nil {
+		return "", err
+	}
+	defer ep.rl.Close()
+
+	numlines := ep.render("")
+	_, err = ep.rl.Readline()
+	if err != nil {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
+			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
+		}
+		ep.rl.Write([]byte("\n"))
+		return "", ep.canceled(err)
+	}
+	ep.clear(numlines)
+
+	for {
+		ec := EditorConfig{
+			Editor:     ep.Editor,
+			Ext:        ep.Ext,
+			Help:       ep.EditorHelp,
+			AbortEmpty: ep.AbortEmpty,
+		}
+		// ep.out keeps the raw text to edit it again,
+		// it is formatted once accepted
+		ep.out, err = ec.Edit(ep.out)
+		if err != nil {
+			return "", err
+		}
+
+		msg := ""
+		ep.state = ep.IconGood
+		choices := []Choice{
+			{Key: 'a', Help: "accept the value"},
+			{Key: 'e', Help: "edit the value again"},
+			{Key: 'c', Help: "cancel"},
+		}
+		def := "a"
+		oerr := ep.validFn(ep.out)
+		ep.validated(ep.out, oerr)
+		if oerr != nil {
+			verr, ok := oerr.(*ValidationError)
+			if !ok {
+				return "", oerr
+			}
+			msg = verr.msg
+			ep.state = ep.IconBad
+			choices = choices[1:]
+			def = "e"
+		}
+
+		numlines = ep.render(msg)
+		cp := ChoicePrompt{
+			BasicPrompt: BasicPrompt{
+				Label:   "Accept the value",
+				Default: def,
+				Indent:  ep.Indent,
+				NoIcons: ep.NoIcons,
+				KeyMap:  ep.KeyMap,
+				KeyHelp: ep.KeyHelp,
+				stdin:   ep.stdin,
+				stdout:  ep.stdout,
+			},
+			Choices: choices,
+		}
+		if msg != "" {
+			cp.Label = "Edit the value"
+		}
+		key, err := cp.Run()
+		ep.clear(numlines + 1)
+		if err != nil {
+			return "", ep.canceled(err)
+		}
+		switch key {
+		case 'a':
+			ep.out = ep.Formatter(ep.out)
+			ep.submitted(ep.out)
+			ep.rl.Write([]byte(ep.Indent + ep.state + " " + ep.prompt +
+				ep.InputResult(ep.preview()[0]) + "\n"))
+			return ep.out, nil
+		case 'c':
+			return "", ErrAbort
+		}
+	}
+}
+
+// render writes the prompt header, value preview and validation message.
+// Returns the number of lines written
+func (ep *EditorPrompt) render(msg string) uint {
+	lines := ep.preview()
+	ep.rl.Write([]byte(ep.Indent + ep.state + " " + ep.PromptInitial(ep.prompt) + "\n"))
+	for _, l := range lines {
+		ep.rl.Write([]byte(ep.Indent + "  " + ep.InputResult(l) + "\n"))
+	}
+	numlines := uint(len(lines)) + 1
+	if msg != "" {
+		ep.rl.Write([]byte(ep.Indent + red("Error: ") + msg + "\n"))
+		numlines++
+	}
+	return numlines
+}
+
+// clear removes numlines lines above the cursor
+func (ep *EditorPrompt) clear(numlines uint) {
+	ep.rl.Write([]byte(clearLine + strings.Repeat(upLine(1)+clearLine, int(numlines)) + "\r"))
+}
+
+// preview returns the value truncated to PreviewLines lines
+// of PreviewWidth runes
+func (ep *EditorPrompt) preview() []string {
+	lines := strings.Split(strings.TrimRight(ep.out, "\r\n"), "\n")
+	more := 0
+	if len(lines) > ep.PreviewLines {
+		more = len(lines) - ep.PreviewLines
+		lines = lines[:ep.PreviewLines]
+	}
+	out := make([]string, 0, len(lines)+1)
+	for _, l := range lines {
+		rs := []rune(strings.TrimRight(l, "\r"))
+		if len(rs) > ep.PreviewWidth {
+			rs = append(rs[:ep.PreviewWidth-1], '…')
+		}
+		out = append(out, string(rs))
+	}
+	if more > 0 {
+		out = append(out, fmt.Sprintf("… %d more lines", more))
+	}
+	return out
+}
+
+// EditValue func is predefined easy editor prompt
+func EditValue(label, value string) (string, error) {
+	ep := EditorPrompt{
+		BasicPrompt: BasicPrompt{
+			Label:   label,
+			Default: value,
+		},
+	}
+	return ep.Run()
+}
@@ -0,0 +1,77 @@
+package promptui
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestEditorPreview(t *testing.T) {
+	ep := EditorPrompt{PreviewLines: 2, PreviewWidth: 5}
+	ep.out = "first line\nsecond\nthird\nfourth\n"
+	expected := []string{"firs…", "seco…", "… 2 more lines"}
+	if got := ep.preview(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("wrong preview: %q != %q", got, expected)
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected []string
+	}{
+		{"vim", []string{"vim"}},
+		{"code --wait", []string{"code", "--wait"}},
+		{"  vim   -u NONE ", []string{"vim", "-u", "NONE"}},
+		{`"/opt/my editor/bin/ed" -x`, []string{"/opt/my editor/bin/ed", "-x"}},
+		{`emacs --eval '(setq a "b")'`, []string{"emacs", "--eval", `(setq a "b")`}},
+		{`ed ""`, []string{"ed", ""}},
+	}
+	for _, c := range cases {
+		got, err := splitCommand(c.in)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", c.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("wrong args for %q: %q != %q", c.in, got, c.expected)
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		got, _ := splitCommand(`my\ editor`)
+		if !reflect.DeepEqual(got, []string{"my editor"}) {
+			t.Errorf("wrong escaped args: %q", got)
+		}
+	}
+
+	for _, in := range []string{"", "  ", `vim "unterminated`} {
+		if _, err := splitCommand(in); err != ErrorCommand {
+			t.Errorf("expected error for %q, got %v", in, err)
+		}
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	in := "# help line\n#\n\nfix: parser\n\nbody text\n# trailing help\n"
+	expected := "fix: parser\n\nbody text"
+	if got := stripComments(in, "#"); got != expected {
+		t.Errorf("wrong text: %q != %q", got, expected)
+	}
+	if got := stripComments("// c\nkeep # this", "//"); got != "keep # this" {
+		t.Errorf("wrong text for custom marker: %q", got)
+	}
+}
+
+func TestEditorPromptEOF(t *testing.T) {
+	ep := EditorPrompt{
+		BasicPrompt: BasicPrompt{
+			stdin:  &bytes.Buffer{},
+			stdout: &bytes.Buffer{},
+		},
+	}
+	if _, err := ep.Run(); err != ErrEOF {
+		t.Errorf("wrong error: %v != %v", err, ErrEOF)
+	}
+}
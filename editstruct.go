@@ -0,0 +1,99 @@
+package promptui
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrorNotPointer describes non-pointer value passed to StructEditor
+	ErrorNotPointer = errors.New("in promptui:StructEditor: value must be a non-nil pointer")
+)
+
+// StructEditor edits Go values as JSON, YAML or other text in external editor.
+// If the edited text can't be decoded or validated the editor is opened
+// again with the errors inserted as comments at the top.
+type StructEditor struct {
+	EditorConfig
+
+	// Marshal encodes the value, indented JSON by default.
+	// Pass yaml.Marshal with Ext ".yaml" to edit YAML
+	Marshal func(interface{}) ([]byte, error)
+
+	// Unmarshal decodes the edited text, json.Unmarshal by default
+	Unmarshal func([]byte, interface{}) error
+
+	// Validate is optional. If set, it is called with the decoded value,
+	// which is the same type as the value passed to Edit
+	Validate func(interface{}) error
+}
+
+// Edit opens the editor to edit `v`, which must be a non-nil pointer.
+// `v` is updated only if the edited value is decoded and validated.
+// ErrAbort is returned if user empties the text.
+func (se *StructEditor) Edit(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrorNotPointer
+	}
+	ec := se.EditorConfig
+	ec.AbortEmpty = true
+	if ec.Comment == "" {
+		ec.Comment = "#"
+	}
+	marshal := se.Marshal
+	if marshal == nil {
+		marshal = func(v interface{}) ([]byte, error) {
+			return json.MarshalIndent(v, "", "  ")
+		}
+		if ec.Ext == "" {
+			ec.Ext = ".json"
+		}
+	}
+	unmarshal := se.Unmarshal
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	text := string(data)
+	help := se.Help
+	for {
+		ec.Help = "Remove all lines to cancel"
+		if help != "" {
+			ec.Help = help + "\n\n" + ec.Help
+		}
+		text, err = ec.Edit(text)
+		if err != nil {
+			return err
+		}
+
+		nv := reflect.New(rv.Elem().Type())
+		err = unmarshal([]byte(text), nv.Interface())
+		if err == nil && se.Validate != nil {
+			err = se.Validate(nv.Interface())
+		}
+		if err == nil {
+			rv.Elem().Set(nv.Elem())
+			return nil
+		}
+
+		help = "Error: " + strings.Replace(err.Error(), "\n", "\n  ", -1)
+		if se.Help != "" {
+			help += "\n\n" + se.Help
+		}
+	}
+}
+
+// EditStruct func opens default editor to edit `v` as JSON
+func EditStruct(v interface{}, validate func(interface{}) error) error {
+	se := StructEditor{
+		Validate: validate,
+	}
+	return se.Edit(v)
+}
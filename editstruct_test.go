@@ -0,0 +1,61 @@
+package promptui
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestStructEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor is a shell script")
+	}
+	type config struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+
+	t.Run("decodes edited value", func(t *testing.T) {
+		v := config{Name: "api", Port: 80}
+		se := StructEditor{
+			EditorConfig: EditorConfig{
+				Editor: `sh -c 'printf "{\"name\":\"web\",\"port\":8080}" > "$0"'`,
+			},
+		}
+		if err := se.Edit(&v); err != nil {
+			t.Fatalf("error during edit: %s", err)
+		}
+		if v.Name != "web" || v.Port != 8080 {
+			t.Errorf("wrong value: %+v", v)
+		}
+	})
+
+	t.Run("reopens editor with validation errors", func(t *testing.T) {
+		v := config{Name: "api", Port: 80}
+		se := StructEditor{
+			EditorConfig: EditorConfig{
+				// first run keeps invalid port, second run sees error
+				// comment and empties the file to cancel
+				Editor: `sh -c 'grep -q "# Error: bad port" "$0" && : > "$0"; exit 0'`,
+			},
+			Validate: func(v interface{}) error {
+				if v.(*config).Port < 1024 {
+					return NewValidationError("bad port")
+				}
+				return nil
+			},
+		}
+		err := se.Edit(&v)
+		if err != ErrAbort {
+			t.Fatalf("expected ErrAbort, got %v", err)
+		}
+		if v.Port != 80 {
+			t.Errorf("value changed on abort: %+v", v)
+		}
+	})
+
+	t.Run("requires a pointer", func(t *testing.T) {
+		if err := EditStruct(config{}, nil); err != ErrorNotPointer {
+			t.Errorf("expected ErrorNotPointer, got %v", err)
+		}
+	})
+}
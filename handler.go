@@ -0,0 +1,74 @@
+package promptui
+
+import "github.com/karantin2020/readline"
+
+// KeyHandler handles a key before it is applied to the input line.
+// It gets the line and cursor position before the key. Like readline
+// listeners, it returns the new line and position and true if the line
+// is replaced, then the key is consumed. The replaced line is validated
+// as typed input
+type KeyHandler func(st *PromptState, line []rune, pos int, key rune) ([]rune, int, bool)
+
+// PromptState gives key handlers access to the running prompt
+type PromptState struct {
+	icon     string
+	message  string
+	selected int
+	action   Action
+}
+
+// SetIcon replaces the prompt state icon, empty icon restores the default one
+func (st *PromptState) SetIcon(icon string) {
+	st.icon = icon
+}
+
+// SetMessage shows the message under the prompt, empty message hides it.
+// Validation errors take precedence
+func (st *PromptState) SetMessage(msg string) {
+	st.message = msg
+}
+
+// Submit submits the input as if the submit key is pressed,
+// the input is still validated
+func (st *PromptState) Submit() {
+	st.action = ActionSubmit
+}
+
+// Cancel interrupts the prompt, ErrInterrupt is returned
+func (st *PromptState) Cancel() {
+	st.action = ActionCancel
+}
+
+// Selected returns the index of the highlighted Select item,
+// -1 for other prompts
+func (st *PromptState) Selected() int {
+	return st.selected
+}
+
+// iconOr returns the icon set by handlers or def
+func (st *PromptState) iconOr(def string) string {
+	if st.icon != "" {
+		return st.icon
+	}
+	return def
+}
+
+// handle calls handlers in order, the line replaced by a handler is passed
+// to the next ones. It returns the key translated by Submit or Cancel,
+// the line and true if it is replaced
+func (st *PromptState) handle(handlers []KeyHandler, line []rune, pos int, key rune) (rune, []rune, int, bool) {
+	st.action = ""
+	replaced := false
+	for _, h := range handlers {
+		if l, p, ok := h(st, line, pos, key); ok {
+			line, pos, replaced = l, p, true
+		}
+	}
+	switch st.action {
+	case ActionSubmit:
+		key = readline.CharEnter
+	case ActionCancel:
+		key = readline.CharInterrupt
+	}
+	return key, line, pos, replaced
+}
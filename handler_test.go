@@ -0,0 +1,67 @@
+package promptui
+
+import (
+	"testing"
+
+	"github.com/karantin2020/readline"
+)
+
+func TestPromptStateHandle(t *testing.T) {
+	upper := func(st *PromptState, line []rune, pos int, key rune) ([]rune, int, bool) {
+		if key < 'a' || key > 'z' {
+			return nil, 0, false
+		}
+		line = append(append(line[:pos:pos], key-'a'+'A'), line[pos:]...)
+		return line, pos + 1, true
+	}
+	count := func(st *PromptState, line []rune, pos int, key rune) ([]rune, int, bool) {
+		st.SetMessage(string(line))
+		return nil, 0, false
+	}
+
+	t.Run("replaces the line in order", func(t *testing.T) {
+		st := &PromptState{selected: -1}
+		key, line, pos, replaced := st.handle([]KeyHandler{upper, count}, []rune("AC"), 1, 'b')
+		if !replaced || string(line) != "ABC" || pos != 2 || key != 'b' {
+			t.Errorf("wrong result: %q %d %v", string(line), pos, replaced)
+		}
+		if st.message != "ABC" {
+			t.Errorf("wrong message: %s != %s", st.message, "ABC")
+		}
+	})
+
+	t.Run("translates actions", func(t *testing.T) {
+		st := &PromptState{}
+		submit := func(st *PromptState, line []rune, pos int, key rune) ([]rune, int, bool) {
+			if key == ' ' {
+				st.Submit()
+			}
+			if key == 'q' {
+				st.Cancel()
+			}
+			return nil, 0, false
+		}
+		cases := map[rune]rune{
+			' ': readline.CharEnter,
+			'q': readline.CharInterrupt,
+			'x': 'x',
+		}
+		for in, want := range cases {
+			key, _, _, _ := st.handle([]KeyHandler{submit}, nil, 0, in)
+			if key != want {
+				t.Errorf("wrong key for %q: %q != %q", in, key, want)
+			}
+		}
+	})
+
+	t.Run("icon", func(t *testing.T) {
+		st := &PromptState{}
+		if st.iconOr("?") != "?" {
+			t.Errorf("wrong default icon")
+		}
+		st.SetIcon("!")
+		if st.iconOr("?") != "!" {
+			t.Errorf("wrong icon: %s != %s", st.iconOr("?"), "!")
+		}
+	})
+}
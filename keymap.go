@@ -0,0 +1,169 @@
+package promptui
+
+import (
+	"strings"
+
+	"github.com/karantin2020/readline"
+)
+
+// Action is a named prompt action bound to keys with KeyMap
+type Action string
+
+// Prompt actions
+const (
+	// ActionSubmit submits the input
+	ActionSubmit Action = "submit"
+	// ActionCancel interrupts the prompt, ErrInterrupt is returned
+	ActionCancel Action = "cancel"
+	// ActionNext moves to the next item of lists
+	ActionNext Action = "next"
+	// ActionPrev moves to the previous item of lists
+	ActionPrev Action = "prev"
+	// ActionToggleMask shows and hides the input of masked prompts
+	ActionToggleMask Action = "toggle-mask"
+	// ActionSelectAll selects all items of prompts with multiple selection
+	ActionSelectAll Action = "select-all"
+	// ActionHelp shows prompt help
+	ActionHelp Action = "help"
+)
+
+// actionKeys are readline keys the actions are translated to
+var actionKeys = map[Action]rune{
+	ActionSubmit: readline.CharEnter,
+	ActionCancel: readline.CharInterrupt,
+	ActionNext:   readline.CharNext,
+	ActionPrev:   readline.CharPrev,
+}
+
+// KeyMap binds keys to prompt actions. Every prompt consults
+// the actions it supports, e.g. Prompt uses submit, cancel and toggle-mask,
+// Select uses submit, cancel, next and prev.
+type KeyMap map[Action][]rune
+
+// EmacsKeyMap returns the default key bindings
+func EmacsKeyMap() KeyMap {
+	return KeyMap{
+		ActionSubmit:     {readline.CharEnter},
+		ActionCancel:     {readline.CharInterrupt},
+		ActionNext:       {readline.CharNext},
+		ActionPrev:       {readline.CharPrev},
+		ActionToggleMask: {'*'},
+		ActionSelectAll:  {readline.CharLineStart},
+		ActionHelp:       {'?'},
+	}
+}
+
+// VimKeyMap returns key bindings for vim mode. Ctrl-j and Ctrl-k move
+// in lists additionally to arrows. Modal keys like `j`, `k`, `q` and Esc
+// are handled by Select in vim mode
+func VimKeyMap() KeyMap {
+	km := EmacsKeyMap()
+	km[ActionNext] = []rune{readline.CharNext, readline.CharCtrlJ}
+	km[ActionPrev] = []rune{readline.CharPrev, readline.CharKill}
+	km[ActionSelectAll] = []rune{'a'}
+	return km
+}
+
+// Override returns a copy of the key map with actions of o rebound
+func (km KeyMap) Override(o KeyMap) KeyMap {
+	out := KeyMap{}
+	for a, keys := range km {
+		out[a] = keys
+	}
+	for a, keys := range o {
+		out[a] = keys
+	}
+	return out
+}
+
+// Is reports if the key is bound to the action
+func (km KeyMap) Is(key rune, a Action) bool {
+	for _, k := range km[a] {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the readline key of the action the key is bound to,
+// only given actions are checked. The key is returned as is if it is not
+// bound to them. It returns false if the key is the readline key of an
+// action rebound to other keys, such key must be ignored
+func (km KeyMap) resolve(key rune, actions ...Action) (rune, bool) {
+	for _, a := range actions {
+		if km.Is(key, a) {
+			return actionKeys[a], true
+		}
+	}
+	for _, a := range actions {
+		if k, ok := actionKeys[a]; ok && k == key {
+			return key, false
+		}
+	}
+	return key, true
+}
+
+// defaultKeyMap returns the preset for vim or emacs mode
+func defaultKeyMap(vim bool) KeyMap {
+	if vim {
+		return VimKeyMap()
+	}
+	return EmacsKeyMap()
+}
+
+// binding is a key handling of a prompt described in the help line
+type binding struct {
+	// actions keys are taken from KeyMap
+	actions []Action
+	// keys are names of keys handled by the prompt directly
+	keys []string
+	desc string
+}
+
+// help returns the help line listing the bindings with actual keys
+func (km KeyMap) help(bindings ...binding) string {
+	var parts []string
+	for _, b := range bindings {
+		var names []string
+		for _, a := range b.actions {
+			for _, k := range km[a] {
+				names = append(names, keyName(k))
+			}
+		}
+		names = append(names, b.keys...)
+		if len(names) == 0 {
+			continue
+		}
+		parts = append(parts, strings.Join(names, "/")+" to "+b.desc)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keyName returns the key name shown in help
+func keyName(k rune) string {
+	switch k {
+	case readline.CharEnter:
+		return "Enter"
+	case readline.CharTab:
+		return "Tab"
+	case readline.CharEsc:
+		return "Esc"
+	case readline.CharNext:
+		return "↓"
+	case readline.CharPrev:
+		return "↑"
+	case readline.CharForward:
+		return "→"
+	case readline.CharBackward:
+		return "←"
+	case readline.CharBackspace:
+		return "Backspace"
+	case ' ':
+		return "Space"
+	}
+	if k > 0 && k < ' ' {
+		return "Ctrl-" + string('A'+k-1)
+	}
+	return string(k)
+}
@@ -0,0 +1,80 @@
+package promptui
+
+import (
+	"testing"
+
+	"github.com/karantin2020/readline"
+)
+
+func TestKeyMap(t *testing.T) {
+	t.Run("presets", func(t *testing.T) {
+		if !EmacsKeyMap().Is('*', ActionToggleMask) {
+			t.Errorf("wrong toggle-mask key")
+		}
+		if !VimKeyMap().Is(readline.CharCtrlJ, ActionNext) {
+			t.Errorf("wrong vim next key")
+		}
+		if VimKeyMap().Is(readline.CharCtrlJ, ActionPrev) {
+			t.Errorf("wrong vim prev key")
+		}
+	})
+
+	t.Run("override", func(t *testing.T) {
+		base := EmacsKeyMap()
+		km := base.Override(KeyMap{ActionToggleMask: {readline.CharTranspose}})
+		if !km.Is(readline.CharTranspose, ActionToggleMask) || km.Is('*', ActionToggleMask) {
+			t.Errorf("wrong overridden toggle-mask keys: %v", km[ActionToggleMask])
+		}
+		if !base.Is('*', ActionToggleMask) {
+			t.Errorf("base key map is changed")
+		}
+		if !km.Is(readline.CharEnter, ActionSubmit) {
+			t.Errorf("wrong submit keys: %v", km[ActionSubmit])
+		}
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		km := EmacsKeyMap().Override(KeyMap{ActionSubmit: {readline.CharCtrlW}})
+		cases := []struct {
+			key  rune
+			want rune
+			ok   bool
+		}{
+			{readline.CharCtrlW, readline.CharEnter, true},
+			{readline.CharEnter, readline.CharEnter, false},
+			{readline.CharInterrupt, readline.CharInterrupt, true},
+			{'a', 'a', true},
+		}
+		for _, c := range cases {
+			got, ok := km.resolve(c.key, ActionSubmit, ActionCancel)
+			if got != c.want || ok != c.ok {
+				t.Errorf("wrong resolve of %q: %q %v != %q %v", c.key, got, ok, c.want, c.ok)
+			}
+		}
+		if got, ok := km.resolve(readline.CharCtrlW, ActionCancel); got != readline.CharCtrlW || !ok {
+			t.Errorf("wrong resolve of unchecked action: %q %v", got, ok)
+		}
+	})
+
+	t.Run("help", func(t *testing.T) {
+		bindings := []binding{
+			{actions: []Action{ActionPrev, ActionNext}, desc: "move"},
+			{keys: []string{"Tab"}, desc: "complete"},
+			{actions: []Action{ActionSubmit}, desc: "pick"},
+			{actions: []Action{ActionSelectAll}, desc: "select all"},
+		}
+		cases := []struct {
+			km   KeyMap
+			want string
+		}{
+			{EmacsKeyMap(), "↑/↓ to move, Tab to complete, Enter to pick, Ctrl-A to select all"},
+			{VimKeyMap(), "↑/Ctrl-K/↓/Ctrl-J to move, Tab to complete, Enter to pick, a to select all"},
+			{KeyMap{ActionSubmit: {readline.CharCtrlW}}, "Tab to complete, Ctrl-W to pick"},
+		}
+		for _, c := range cases {
+			if got := c.km.help(bindings...); got != c.want {
+				t.Errorf("wrong help: %s != %s", got, c.want)
+			}
+		}
+	})
+}
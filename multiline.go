@@ -1,16 +1,16 @@
 package promptui
 
 import (
-	"bytes"
+	"fmt"
 	"io"
-	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/karantin2020/readline"
 )
 
-// MultilinePrompt represents a single line text field input.
+// MultilinePrompt represents a multiline text area input.
+// Arrows move the cursor across lines, Enter inserts a new line,
+// Ctrl-D or Alt-Enter finishes the input. Vim mode is not supported.
 type MultilinePrompt struct {
 	BasicPrompt
 
@@ -20,6 +20,30 @@ type MultilinePrompt struct {
 
 	// Editor is default editor to edit multiline
 	Editor string
+
+	// EditorHelp is shown as comment lines in editor and stripped on read
+	EditorHelp string
+
+	// AbortEmpty makes Run return ErrAbort if the text edited in editor
+	// is empty
+	AbortEmpty bool
+
+	// Height is the max number of visible lines, 10 by default.
+	// Text with more lines is scrolled
+	Height int
+
+	// NoLineNumbers hides line numbers
+	NoLineNumbers bool
+
+	// ValidateLine is optional. If set, this function is used to validate
+	// every line after each character entry. Invalid lines are marked
+	// and the first error is shown under the text
+	ValidateLine ValidateFunc
+
+	area     *textArea
+	screen   *screen
+	lineErrs map[int]string
+	errMsg   string
 }
 
 // Run func implements multiline prompt logic
@@ -28,72 +52,74 @@ func (mp *MultilinePrompt) Run() (string, error) {
 	if err != nil {
 		return "", err
 	}
-
-	mp.c.Stdin = ioutil.NopCloser(io.MultiReader(bytes.NewBuffer([]byte(mp.Default)), os.Stdin))
-
-	mp.rl, err = readline.NewEx(mp.c)
-	if err != nil {
-		return "", err
+	if mp.Height <= 0 {
+		mp.Height = 10
 	}
-	mp.suggestedAnswer = " " + faint("Two empty lines to finish")
+
+	mp.c.VimMode = false
+	mp.c.Stdin = &keyStdin{mp.c.Stdin, submitKeys}
+	mp.suggestedAnswer = " " + faint("Ctrl-D to finish")
 	mp.prompt = mp.LabelInitial(mp.Label) + mp.punctuation + mp.suggestedAnswer + " "
-	mp.c.UniqueEditLine = false
-	var (
-		firstListen      = true
-		numlines    uint = 1
-		breaklines       = 0
-		out         string
-	)
-
-	mp.rl.Write([]byte(mp.Indent + mp.state + " " + mp.PromptInitial(mp.prompt) + "\n"))
-	mp.rl.SetPrompt("... ")
-	mp.rl.Refresh()
-	var multilineReader = func(line []rune, pos int, key rune) ([]rune, int, bool) {
-		if firstListen {
-			firstListen = false
-		}
-		return nil, 0, false
-	}
-	mp.c.SetListener(multilineReader)
+	mp.area = newTextArea(mp.Default, mp.Height)
+	mp.screen = &screen{w: mp.c.Stdout}
+	mp.c.Prompt = mp.footer()
 
-	for {
-		out, err = mp.rl.Readline()
-		numlines++
-		if out == "" {
-			breaklines++
-		} else {
-			breaklines = 0
+	mp.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
 		}
-
-		mp.out += out
-
-		if breaklines > 1 {
-			break
+		if mp.toggleHelp(r, mp.area.String() == "") {
+			mp.rl.SetPrompt(mp.footer())
+			return r, false
 		}
-
-		if err != nil {
-			switch err {
-			case readline.ErrInterrupt:
-				err = ErrInterrupt
-			case io.EOF:
-				err = ErrEOF
+		r, ok := mp.KeyMap.resolve(r, ActionCancel)
+		if !ok {
+			return r, false
+		}
+		switch r {
+		case readline.CharInterrupt:
+			return r, true
+		case keySubmit:
+			// invalid text stays in the editor with the error in the footer
+			return readline.CharEnter, mp.errMsg == ""
+		}
+		prev := mp.area.String()
+		if mp.area.handle(r) {
+			if text := mp.area.String(); text != prev {
+				mp.changed(text)
 			}
-
-			break
+			mp.liveValidate()
+			mp.draw()
+			mp.rl.SetPrompt(mp.footer())
 		}
+		return r, false
+	}
+	mp.liveValidate()
 
-		mp.rl.SetPrompt("... ")
-		mp.rl.Refresh()
-		mp.out += "\n"
+	mp.rl, err = readline.NewEx(mp.c)
+	if err != nil {
+		return "", err
 	}
 
+	mp.rl.Write([]byte(hideCursor))
+	mp.draw()
+	_, err = mp.rl.Readline()
+	mp.rl.Write([]byte(showCursor))
+	mp.out = mp.area.String()
+
 	if err != nil {
-		if err.Error() == "Interrupt" {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
 			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
 		}
-		return "", err
+		mp.rl.Close()
+		return "", mp.canceled(err)
 	}
 
+	numlines := mp.screen.drawn
 	defer mp.rl.Close()
 
 	var clearLines = func(numlines uint) {
@@ -122,7 +148,12 @@ func (mp *MultilinePrompt) Run() (string, error) {
 				return mp.out, oerr
 			}
 			if yn == "Y" {
-				mp.out, oerr = Editor(mp.Editor, mp.out)
+				ec := EditorConfig{
+					Editor:     mp.Editor,
+					Help:       mp.EditorHelp,
+					AbortEmpty: mp.AbortEmpty,
+				}
+				mp.out, oerr = ec.Edit(mp.out)
 				if oerr != nil {
 					return mp.out, oerr
 				}
@@ -136,16 +167,90 @@ func (mp *MultilinePrompt) Run() (string, error) {
 				break
 			}
 		}
+		mp.submitted(mp.out)
 		break
 	}
 	return mp.out, err
 }
 
+// draw redraws the prompt header and visible text lines above the footer
+func (mp *MultilinePrompt) draw() {
+	lines := []string{mp.Indent + mp.state + " " + mp.PromptInitial(mp.prompt)}
+	width := mp.c.FuncGetWidth() - len([]rune(mp.Indent))
+	for _, l := range mp.area.render(!mp.NoLineNumbers, width, mp.InputInitial, mp.lineErrs) {
+		lines = append(lines, mp.Indent+l)
+	}
+	mp.screen.draw(lines)
+}
+
+// liveValidate validates the text lines and the whole text while typing,
+// it updates the header state and error messages
+func (mp *MultilinePrompt) liveValidate() {
+	mp.lineErrs = map[int]string{}
+	mp.errMsg = ""
+	if mp.ValidateLine != nil {
+		for i, l := range mp.area.lines {
+			if err := mp.ValidateLine(string(l)); err != nil {
+				mp.lineErrs[i] = err.Error()
+				if mp.errMsg == "" {
+					mp.errMsg = fmt.Sprintf("line %d: %s", i+1, err)
+				}
+			}
+		}
+	}
+	text := mp.area.String()
+	err := mp.validFn(text)
+	if err != nil && mp.errMsg == "" {
+		mp.errMsg = err.Error()
+	}
+	if err == nil && mp.errMsg != "" {
+		err = NewValidationError(mp.errMsg)
+	}
+	mp.validated(text, err)
+	switch {
+	case mp.errMsg != "":
+		mp.state = mp.IconBad
+	case text == "":
+		mp.state = mp.IconInitial
+	default:
+		mp.state = mp.IconGood
+	}
+}
+
+// footer returns the line under the text with the first validation error,
+// key bindings or cursor position
+func (mp *MultilinePrompt) footer() string {
+	if mp.errMsg != "" {
+		return mp.Indent + "  " + red("Error: ") + mp.errMsg
+	}
+	if help := mp.keyHelp(
+		binding{keys: []string{"Ctrl-D", "Alt-Enter"}, desc: "submit"},
+		binding{actions: []Action{ActionCancel}, desc: "cancel"},
+	); help != "" {
+		return help
+	}
+	ta := mp.area
+	pos := fmt.Sprintf("Ln %d, Col %d", ta.row+1, ta.col+1)
+	if start, end := ta.visible(); start > 0 || end < len(ta.lines) {
+		pos += fmt.Sprintf(", %d-%d of %d lines", start+1, end, len(ta.lines))
+	}
+	return mp.Indent + "  " + faint(pos)
+}
+
 func (mp *MultilinePrompt) formatAndValidate() (msg string, oerr error) {
 	mp.out = strings.Trim(mp.out, "\n\r")
 	mp.out = mp.Formatter(mp.out)
 
 	oerr = mp.validFn(mp.out)
+	if oerr == nil && mp.ValidateLine != nil {
+		for i, l := range strings.Split(mp.out, "\n") {
+			if lerr := mp.ValidateLine(l); lerr != nil {
+				oerr = NewValidationError(fmt.Sprintf("line %d: %s", i+1, lerr))
+				break
+			}
+		}
+	}
+	mp.validated(mp.out, oerr)
 	if oerr != nil {
 		if verr, ok := oerr.(*ValidationError); ok {
 			msg = verr.msg
@@ -0,0 +1,31 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMultilinePromptInvalidSubmit(t *testing.T) {
+	in := bytes.Buffer{}
+	mp := MultilinePrompt{
+		BasicPrompt: BasicPrompt{
+			Label:  "test",
+			stdin:  &in,
+			stdout: &bytes.Buffer{},
+		},
+		ValidateLine: func(line string) error {
+			if strings.Contains(line, "x") {
+				return errors.New("no x")
+			}
+			return nil
+		},
+	}
+
+	// the submit is refused and the text is kept until stdin is closed
+	in.Write([]byte("ax\x04"))
+	if res, err := mp.Run(); res != "" || err != ErrEOF {
+		t.Errorf("wrong result: %q %v != %v", res, err, ErrEOF)
+	}
+}
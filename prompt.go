@@ -1,11 +1,9 @@
 package promptui
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/karantin2020/readline"
@@ -78,12 +76,28 @@ type BasicPrompt struct {
 
 	// IsVimMode option
 	IsVimMode bool
+	// KeyMap binds keys to prompt actions, EmacsKeyMap or VimKeyMap
+	// is used if nil
+	KeyMap KeyMap
+	// KeyHelp shows the line with the active key bindings under the prompt,
+	// the help key hides and shows it
+	KeyHelp bool
 	// Preamble option
 	Preamble *string
 
 	// Formatter formats input result
 	Formatter StyleFn
 
+	// OnChange is called with the input after each change
+	OnChange func(input string)
+	// OnValidate is called with the input and its validation result
+	OnValidate func(input string, err error)
+	// OnSubmit is called with the result when the input is accepted
+	OnSubmit func(result string)
+	// OnCancel is called with ErrInterrupt or ErrEOF when the prompt
+	// is interrupted
+	OnCancel func(err error)
+
 	stdin           io.Reader
 	stdout          io.Writer
 	c               *readline.Config
@@ -94,6 +108,7 @@ type BasicPrompt struct {
 	prompt          string
 	validFn         func(string) error
 	out             string
+	showHelp        bool
 }
 
 // Init func to setup BasicPrompt
@@ -157,12 +172,16 @@ func (bp *BasicPrompt) Init() error {
 	if bp.Formatter == nil {
 		bp.Formatter = func(s string) string { return s }
 	}
+	if bp.KeyMap == nil {
+		bp.KeyMap = defaultKeyMap(bp.IsVimMode)
+	}
 	bp.c.Painter = &defaultPainter{style: bp.InputInitial}
 
 	bp.suggestedAnswer = ""
 	bp.punctuation = ":"
 	bp.c.UniqueEditLine = true
 
+	bp.showHelp = bp.KeyHelp
 	bp.state = bp.IconInitial
 	bp.prompt = bp.LabelInitial(bp.Label) + bp.punctuation + bp.suggestedAnswer + " "
 
@@ -182,18 +201,101 @@ func (bp *BasicPrompt) Init() error {
 	return nil
 }
 
+// changed calls OnChange if it is set
+func (bp *BasicPrompt) changed(input string) {
+	if bp.OnChange != nil {
+		bp.OnChange(input)
+	}
+}
+
+// validated calls OnValidate if it is set
+func (bp *BasicPrompt) validated(input string, err error) {
+	if bp.OnValidate != nil {
+		bp.OnValidate(input, err)
+	}
+}
+
+// submitted calls OnSubmit if it is set
+func (bp *BasicPrompt) submitted(result string) {
+	if bp.OnSubmit != nil {
+		bp.OnSubmit(result)
+	}
+}
+
+// canceled calls OnCancel if err is ErrInterrupt or ErrEOF,
+// err is returned as is
+func (bp *BasicPrompt) canceled(err error) error {
+	if bp.OnCancel != nil && (err == ErrInterrupt || err == ErrEOF) {
+		bp.OnCancel(err)
+	}
+	return err
+}
+
+// toggleHelp hides or shows the key bindings line if r is the help key.
+// Text prompts only toggle it while the input is empty
+func (bp *BasicPrompt) toggleHelp(r rune, empty bool) bool {
+	if !bp.KeyHelp || !empty || !bp.KeyMap.Is(r, ActionHelp) {
+		return false
+	}
+	bp.showHelp = !bp.showHelp
+	return true
+}
+
+// keyHelp returns the key bindings line if it is shown
+func (bp *BasicPrompt) keyHelp(bindings ...binding) string {
+	if !bp.showHelp {
+		return ""
+	}
+	bindings = append(bindings, binding{actions: []Action{ActionHelp}, desc: "hide help"})
+	return bp.Indent + "  " + faint(bp.KeyMap.help(bindings...))
+}
+
+// withFooter returns the readline prompt with the footer line under it
+func withFooter(prompt, footer string) string {
+	if footer == "" {
+		return prompt
+	}
+	return "\n" + footer + upLine(1) + "\r" + prompt
+}
+
 // Prompt represents a single line text field input.
 type Prompt struct {
 	BasicPrompt
 
 	// If mask is set, this value is displayed instead of the actual input
-	// characters.
+	// characters. Default of the masked prompt is opaque: a fixed mask is
+	// shown instead, it is submitted on Enter and replaced by the input
+	// on the first key.
 	Mask rune
 
-	// Handlers catch key input events, user defined
-	Handlers []func(line []rune, pos int, key rune) ([]rune, int, bool)
+	// Handlers are called in order for every key before it is applied
+	// to the line, after KeyMap keys like toggle-mask are handled.
+	// They get the unmasked input, the line they replace is validated
+	Handlers []KeyHandler
+
+	// Transforms shape the input while typing, e.g. UpperCase or
+	// MaxLength(8). They are applied in order after each key, before
+	// validation
+	Transforms []Transform
+
+	// InputMask shapes the input after Transforms, like `(999) 999-9999`
+	// or `XXXX-XXXX`. `9` is a digit, `A` is a letter, `X` is a letter
+	// or a digit, other runes are literals inserted while typing.
+	// The rest of the mask is shown faint
+	InputMask string
+
+	// Placeholder is shown faint while the input is empty. Unlike Default,
+	// it isn't part of the input
+	Placeholder string
+
+	// maxAttempts limits invalid submits, 0 means unlimited
+	maxAttempts int
 }
 
+// secretLen is the length of the mask shown for the opaque default,
+// it doesn't depend on the default length
+const secretLen = 8
+
 // Run runs the prompt, returning the validated input.
 func (p *Prompt) Run() (string, error) {
 	err := p.Init()
@@ -201,83 +303,222 @@ func (p *Prompt) Run() (string, error) {
 		return "", err
 	}
 
-	p.c.Stdin = ioutil.NopCloser(io.MultiReader(bytes.NewBuffer([]byte(p.Default)), os.Stdin))
-
 	p.rl, err = readline.NewEx(p.c)
 	if err != nil {
 		return "", err
 	}
 
-	var (
-		firstListen = true
-		wroteErr    = false
-		caughtup    = true
-	)
-
-	if p.Default != "" {
-		caughtup = false
-	}
+	attempts := 0
 
 	if p.Mask != 0 {
 		p.c.EnableMask = true
 		p.c.MaskRune = p.Mask
 	}
+	painter := p.c.Painter.(*defaultPainter)
+	painter.mask = []rune(p.InputMask)
+	painter.placeholder = p.Placeholder
+
+	bindings := []binding{
+		{actions: []Action{ActionSubmit}, desc: "submit"},
+		{actions: []Action{ActionCancel}, desc: "cancel"},
+	}
+	if p.Mask != 0 {
+		bindings = append(bindings, binding{actions: []Action{ActionToggleMask}, desc: "show/hide input"})
+	}
+	var errMsg string
+	st := &PromptState{selected: -1}
+	var setPrompt = func() {
+		footer := p.keyHelp(bindings...)
+		if st.message != "" {
+			footer = p.Indent + "  " + st.message
+		}
+		if errMsg != "" {
+			footer = red("Error: ") + errMsg
+		}
+		p.rl.SetPrompt(withFooter(p.Indent+st.iconOr(p.state)+" "+p.PromptInitial(p.prompt), footer))
+	}
+	setPrompt()
+
+	// check validates the line and updates the state icon,
+	// errors other than ValidationError are returned
+	var check = func(line []rune) error {
+		err := p.validFn(string(line))
+		p.validated(string(line), err)
+		if err != nil {
+			if _, ok := err.(*ValidationError); !ok {
+				return err
+			}
+			p.state = p.IconBad
+		} else if len(line) == 0 {
+			p.state = p.IconInitial
+		} else {
+			p.state = p.IconGood
+		}
+		return nil
+	}
+
+	// opaque is true while the masked default is kept out of the input
+	def := []rune(p.Default)
+	opaque := p.Mask != 0 && len(def) > 0
+	if opaque {
+		def = nil
+		painter.secret = strings.Repeat(string(p.Mask), secretLen)
+	}
+
+	// cur is the line before the next key, pending is the line replaced
+	// by handlers, it is set by the listener. tail is the seeded line right
+	// of the cursor, it is painted after the buffer until the first key
+	var (
+		cur, curPos, _ = p.transform(def, len(def))
+		pending        []rune
+		pendingPos     int
+		tail           []rune
+	)
+	var setTail = func(t []rune) {
+		tail = t
+		painter.tail = string(t)
+	}
+	// seed sets the edit buffer of the next Readline call to cur with
+	// the cursor at curPos. readline puts the cursor at the end of the
+	// buffer, so only the line left of the cursor is set. Masked input
+	// isn't painted, its cursor is put at the end
+	var seed = func() {
+		if p.Mask != 0 {
+			curPos = len(cur)
+		}
+		p.rl.Operation.SetBuffer(string(cur[:curPos]))
+		setTail(append([]rune(nil), cur[curPos:]...))
+	}
+	switch {
+	case opaque:
+		if err := check([]rune(p.Default)); err != nil {
+			return "", err
+		}
+		setPrompt()
+	case len(cur) > 0:
+		if err := check(cur); err != nil {
+			return "", err
+		}
+		setPrompt()
+	}
 
 	var onelineReader = func(line []rune, pos int, key rune) ([]rune, int, bool) {
-		if key == readline.CharEnter {
+		// key is 0 when readline starts, the line is seeded already
+		if key == readline.CharEnter || key == readline.CharCtrlJ || key == 0 {
 			return nil, 0, false
 		}
-		if p.Mask != 0 && key == 42 {
-			p.c.EnableMask = !p.c.EnableMask
-			p.rl.Refresh()
-			return append(line[:pos-1], line[pos:]...), pos - 1, true
+		replaced := false
+		switch {
+		case pending != nil:
+			line, pos, replaced = pending, pendingPos, true
+			pending = nil
+		case len(tail) > 0:
+			// the key is applied left of the cursor, the tail is joined
+			line, replaced = append(line, tail...), true
 		}
+		setTail(nil)
+		if l, ps, ok := p.transform(line, pos); ok {
+			line, pos, replaced = l, ps, true
+		}
+		prev := string(cur)
+		cur, curPos = append([]rune(nil), line...), pos
+		if string(line) == prev {
+			// only the cursor is moved
+			return line, pos, replaced
+		}
+		p.changed(string(line))
 
-		if firstListen {
-			firstListen = false
+		if err := check(line); err != nil {
+			p.rl.Close()
 			return nil, 0, false
 		}
 
-		if !caughtup && p.out != "" {
-			if string(line) == p.out {
-				caughtup = true
-			}
-			if wroteErr {
-				return nil, 0, false
-			}
+		errMsg = ""
+		setPrompt()
+		if !replaced {
+			p.rl.Refresh()
 		}
 
-		err := p.validFn(string(line))
-		if err != nil {
-			if _, ok := err.(*ValidationError); ok {
-				p.state = p.IconBad
-			} else {
-				p.rl.Close()
-				return nil, 0, false
+		return line, pos, replaced
+	}
+
+	p.c.SetListener(onelineReader)
+	// carry replaces the key with a movement to let the listener set
+	// the pending line and cursor. readline stops reading after keys
+	// ending the line, the read is resumed for them
+	var carry = func(key rune) (rune, bool) {
+		switch key {
+		case readline.CharEnter, readline.CharCtrlJ, readline.CharInterrupt, readline.CharDelete:
+			p.rl.Terminal.KickRead()
+		}
+		return readline.CharLineEnd, true
+	}
+	p.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if p.Mask != 0 && p.KeyMap.Is(r, ActionToggleMask) {
+			p.c.EnableMask = !p.c.EnableMask
+			return r, false
+		}
+		if p.toggleHelp(r, len(cur) == 0) {
+			setPrompt()
+			return r, false
+		}
+		r, ok := p.KeyMap.resolve(r, ActionSubmit, ActionCancel)
+		if opaque && ok && r != readline.CharEnter && r != readline.CharCtrlJ && r != readline.CharInterrupt {
+			// the first key drops the default, the input starts empty
+			opaque = false
+			painter.secret = ""
+			p.state = p.IconInitial
+			setPrompt()
+		}
+		if !ok {
+			return r, false
+		}
+		if len(p.Handlers) > 0 {
+			var line []rune
+			var pos int
+			var replaced bool
+			r, line, pos, replaced = st.handle(p.Handlers, cur, curPos, r)
+			switch {
+			case st.action != "":
+				if replaced {
+					setTail(nil)
+					p.rl.Operation.SetBuffer(string(line))
+				}
+				return r, true
+			case replaced:
+				pending, pendingPos = line, pos
+				return carry(r)
 			}
-		} else {
-			if string(line) == "" {
-				p.state = p.IconInitial
-			} else {
-				p.state = p.IconGood
+			setPrompt()
+		}
+		if len(tail) > 0 {
+			// keys editing right of the cursor need the whole line
+			if line, pos, ok := editRight(cur, curPos, r); ok {
+				pending, pendingPos = line, pos
+				return carry(r)
 			}
 		}
-
-		p.rl.SetPrompt(p.Indent + p.state + " " + p.PromptInitial(p.prompt))
-		p.rl.Refresh()
-		wroteErr = false
-
-		return nil, 0, false
+		return r, true
 	}
 
-	p.c.SetListener(onelineReader)
-
 	for {
+		seed()
 		p.out, err = p.rl.Readline()
+		if err == nil {
+			// Enter is the first key, the tail isn't joined
+			p.out += string(tail)
+		}
+		setTail(nil)
+		if opaque && err == nil {
+			p.out = p.Default
+		}
 
 		var msg string
 		valid := true
 		oerr := p.validFn(p.out)
+		if err == nil {
+			p.validated(p.out, oerr)
+		}
 		if oerr != nil {
 			if verr, ok := oerr.(*ValidationError); ok {
 				msg = verr.msg
@@ -305,33 +546,36 @@ func (p *Prompt) Run() (string, error) {
 			break
 		}
 
-		caughtup = false
-
-		p.c.Stdin = ioutil.NopCloser(io.MultiReader(bytes.NewBuffer([]byte(p.out)), os.Stdin))
-		p.rl, _ = readline.NewEx(p.c)
+		attempts++
+		if p.maxAttempts > 0 && attempts >= p.maxAttempts {
+			err = oerr
+			break
+		}
 
-		firstListen = true
-		wroteErr = true
-		p.rl.SetPrompt("\n" + red("Error: ") + msg + upLine(1) + "\r" + p.Indent + p.state + " " + p.PromptInitial(p.prompt))
-		p.rl.Refresh()
+		// the next Readline starts with the rejected input
+		errMsg = msg
+		setPrompt()
 	}
 
-	// if wroteErr {
-	// 	rl.Write([]byte(downLine(1) + clearLine + upLine(1) + "\r"))
-	// }
-
 	if err != nil {
-		if err.Error() == "Interrupt" {
+		switch {
+		case err.Error() == "Interrupt":
 			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
 		}
 		p.rl.Write([]byte("\n"))
-		return "", err
+		return "", p.canceled(err)
 	}
 
 	p.out = p.Formatter(p.out)
+	p.submitted(p.out)
 
 	echo := p.out
-	if p.Mask != 0 {
+	switch {
+	case opaque:
+		echo = painter.secret
+	case p.Mask != 0:
 		echo = strings.Repeat(string(p.Mask), len([]rune(echo)))
 	}
 
@@ -340,12 +584,111 @@ func (p *Prompt) Run() (string, error) {
 	return p.out, err
 }
 
+// transform applies Transforms and InputMask to the line,
+// it returns true if the line or cursor are changed
+func (p *Prompt) transform(line []rune, pos int) ([]rune, int, bool) {
+	out, newPos := line, pos
+	for _, t := range p.Transforms {
+		out, newPos = t(out, newPos)
+	}
+	if p.InputMask != "" {
+		out, newPos = applyMask([]rune(p.InputMask), out, newPos)
+	}
+	return out, newPos, newPos != pos || string(out) != string(line)
+}
+
 type defaultPainter struct {
 	style StyleFn
+	// mask is the input mask, its rest is shown after the line
+	mask []rune
+	// placeholder is shown instead of the empty line
+	placeholder string
+	// secret is the fixed mask of the opaque default,
+	// it is shown instead of the empty line
+	secret string
+	// tail is the seeded line right of the cursor
+	tail string
 }
 
 func (p *defaultPainter) Paint(line []rune, _ int) []rune {
-	return []rune(p.style(string(line)))
+	if len(line) == 0 && p.tail == "" && p.secret != "" {
+		return []rune(p.style(p.secret) + backward(uint(len([]rune(p.secret)))))
+	}
+	if len(line) == 0 && p.tail == "" && p.placeholder != "" {
+		return []rune(faint(p.placeholder) + backward(uint(len([]rune(p.placeholder)))))
+	}
+	out := p.style(string(line) + p.tail)
+	back := len([]rune(p.tail))
+	if rest := maskRest(p.mask, len(line)+back); rest != "" {
+		out += faint(rest)
+		back += len([]rune(rest))
+	}
+	if back > 0 {
+		out += backward(uint(back))
+	}
+	return []rune(out)
+}
+
+// editRight applies readline keys editing the line right of the cursor,
+// like readline does. It returns false for other keys
+func editRight(line []rune, pos int, key rune) ([]rune, int, bool) {
+	out := append([]rune(nil), line...)
+	// wordStart reports if a word starts at i
+	wordStart := func(i int) bool {
+		return !readline.IsWordBreak(out[i]) && readline.IsWordBreak(out[i-1])
+	}
+	switch key {
+	case readline.CharForward:
+		if pos < len(out) {
+			pos++
+		}
+	case readline.CharLineEnd:
+		pos = len(out)
+	case readline.CharKill:
+		out = out[:pos]
+	case readline.CharDelete:
+		if pos < len(out) {
+			out = append(out[:pos], out[pos+1:]...)
+		}
+	case readline.CharTranspose:
+		if len(out) < 2 {
+			pos = len(out)
+			break
+		}
+		if pos == 0 {
+			pos = 1
+		} else if pos >= len(out) {
+			pos = len(out) - 1
+		}
+		out[pos], out[pos-1] = out[pos-1], out[pos]
+		pos++
+	case readline.MetaForward:
+		i := pos + 1
+		for ; i < len(out) && !wordStart(i); i++ {
+		}
+		if i > len(out) {
+			i = len(out)
+		}
+		pos = i
+	case readline.MetaDelete:
+		i := pos
+		for i < len(out) && readline.IsWordBreak(out[i]) {
+			i++
+		}
+		end := len(out)
+		for i++; i < len(out); i++ {
+			if wordStart(i) {
+				end = i - 1
+				break
+			}
+		}
+		if pos < end {
+			out = append(out[:pos], out[end:]...)
+		}
+	default:
+		return nil, 0, false
+	}
+	return out, pos, true
 }
 
 // Ask func is default prompt
@@ -2,7 +2,10 @@ package promptui
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/karantin2020/readline"
 )
 
 func outputTest(mask rune, input, displayed, output, def string) func(t *testing.T) {
@@ -30,7 +33,7 @@ func outputTest(mask rune, input, displayed, output, def string) func(t *testing
 			t.Errorf("wrong result: %s != %s", res, output)
 		}
 
-		expected := "\033[32m✔\033[0m test: \033[2m" + displayed + "\033[0m\n"
+		expected := bold(IconGood) + " test: \033[2m" + displayed + "\033[0m\n"
 		if !bytes.Equal(out.Bytes(), []byte(expected)) {
 			t.Errorf("wrong output: %s != %s", out.Bytes(), expected)
 		}
@@ -42,4 +45,123 @@ func TestPrompt(t *testing.T) {
 	t.Run("can read input", outputTest(0x0, "hi", "hi", "hi", ""))
 	t.Run("displays masked values", outputTest('*', "hi", "**", "hi", ""))
 	t.Run("can use a default", outputTest(0x0, "", "hi", "hi", "hi"))
+	t.Run("hides a masked default", outputTest('*', "", "********", "hi", "hi"))
+}
+
+func TestBasicPromptCallbacks(t *testing.T) {
+	var events []string
+	bp := BasicPrompt{
+		OnChange:   func(input string) { events = append(events, "change "+input) },
+		OnValidate: func(input string, err error) { events = append(events, "validate "+input) },
+		OnSubmit:   func(result string) { events = append(events, "submit "+result) },
+		OnCancel:   func(err error) { events = append(events, "cancel "+err.Error()) },
+	}
+	bp.changed("a")
+	bp.validated("a", nil)
+	bp.submitted("a")
+	if err := bp.canceled(ErrInterrupt); err != ErrInterrupt {
+		t.Errorf("wrong error: %v != %v", err, ErrInterrupt)
+	}
+	bp.canceled(ErrAbort)
+
+	expected := []string{"change a", "validate a", "submit a", "cancel ^C"}
+	if strings.Join(events, ",") != strings.Join(expected, ",") {
+		t.Errorf("wrong events: %v != %v", events, expected)
+	}
+}
+
+func TestDefaultPainter(t *testing.T) {
+	p := &defaultPainter{
+		style:       func(s string) string { return s },
+		placeholder: "name",
+	}
+	if got, expected := string(p.Paint(nil, 0)), faint("name")+backward(4); got != expected {
+		t.Errorf("wrong placeholder: %q != %q", got, expected)
+	}
+	if got := string(p.Paint([]rune("n"), 1)); got != "n" {
+		t.Errorf("wrong line: %q != %q", got, "n")
+	}
+
+	p = &defaultPainter{
+		style: func(s string) string { return s },
+		mask:  []rune("99-99"),
+	}
+	if got, expected := string(p.Paint([]rune("12"), 2)), "12"+faint("-__")+backward(3); got != expected {
+		t.Errorf("wrong mask rest: %q != %q", got, expected)
+	}
+
+	p = &defaultPainter{
+		style:       func(s string) string { return s },
+		placeholder: "password",
+		secret:      "****",
+	}
+	if got, expected := string(p.Paint(nil, 0)), "****"+backward(4); got != expected {
+		t.Errorf("wrong secret: %q != %q", got, expected)
+	}
+
+	p = &defaultPainter{
+		style: func(s string) string { return s },
+		mask:  []rune("99-99"),
+		tail:  "-3",
+	}
+	if got, expected := string(p.Paint([]rune("12"), 2)), "12-3"+faint("_")+backward(3); got != expected {
+		t.Errorf("wrong tail: %q != %q", got, expected)
+	}
+}
+
+func TestEditRight(t *testing.T) {
+	cases := []struct {
+		name   string
+		key    rune
+		in     string
+		pos    int
+		out    string
+		outPos int
+	}{
+		{"forward", readline.CharForward, "abcd", 2, "abcd", 3},
+		{"line end", readline.CharLineEnd, "abcd", 2, "abcd", 4},
+		{"kill", readline.CharKill, "abcd", 2, "ab", 2},
+		{"delete", readline.CharDelete, "abcd", 2, "abd", 2},
+		{"transpose", readline.CharTranspose, "abcd", 2, "acbd", 3},
+		{"next word", readline.MetaForward, "ab cd ef", 1, "ab cd ef", 3},
+		{"delete word", readline.MetaDelete, "ab cd ef", 2, "ab ef", 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, pos, ok := editRight([]rune(c.in), c.pos, c.key)
+			if !ok || string(out) != c.out || pos != c.outPos {
+				t.Errorf("wrong result: %s %d != %s %d", string(out), pos, c.out, c.outPos)
+			}
+		})
+	}
+	if _, _, ok := editRight([]rune("abcd"), 2, 'x'); ok {
+		t.Errorf("unexpected edit of inserted rune")
+	}
+}
+
+func TestPromptRetryKeepsCursor(t *testing.T) {
+	in := bytes.Buffer{}
+	p := Prompt{
+		BasicPrompt: BasicPrompt{
+			Label: "test",
+			Validate: func(s string) error {
+				if len(s) < 5 {
+					return NewValidationError("too short")
+				}
+				return nil
+			},
+			stdin:  &in,
+			stdout: &bytes.Buffer{},
+		},
+	}
+
+	// the cursor is moved before "cd", the rejected input is edited there
+	in.Write([]byte("abcd\x02\x02\nX\n"))
+	res, err := p.Run()
+	if err != nil {
+		t.Fatalf("error during prompt: %s", err)
+	}
+	if res != "abXcd" {
+		t.Errorf("wrong result: %s != %s", res, "abXcd")
+	}
 }
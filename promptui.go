@@ -11,6 +11,7 @@ var ErrEOF = errors.New("^D")
 var ErrInterrupt = errors.New("^C")
 
 // ErrAbort is returned when confirm prompts are supplied "n"
+// or user cancels the prompt
 var ErrAbort = errors.New("")
 
 // ValidateFunc validates the given input. It should return a ValidationError
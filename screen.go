@@ -0,0 +1,39 @@
+package promptui
+
+import (
+	"io"
+	"strings"
+)
+
+// screen redraws a block of lines above the readline prompt line.
+// Readline keeps the prompt on the last line, so the block is drawn
+// directly to the output, not through readline
+type screen struct {
+	w     io.Writer
+	drawn uint
+}
+
+// draw replaces previously drawn lines with lines,
+// the cursor is left at the start of the line under them
+func (s *screen) draw(lines []string) {
+	var b strings.Builder
+	if s.drawn > 0 {
+		b.WriteString(upLine(s.drawn))
+	}
+	b.WriteString("\r")
+	for _, l := range lines {
+		b.WriteString(clearLine + l + "\r\n")
+	}
+	b.WriteString(clearDown)
+	s.drawn = uint(len(lines))
+	s.w.Write([]byte(b.String()))
+}
+
+// clear removes drawn lines, the cursor is left at the start
+// of the first of them
+func (s *screen) clear() {
+	if s.drawn > 0 {
+		s.w.Write([]byte("\r" + upLine(s.drawn) + clearDown))
+	}
+	s.drawn = 0
+}
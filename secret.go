@@ -0,0 +1,211 @@
+package promptui
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/karantin2020/readline"
+)
+
+// SecretPrompt reads a secret like a password or a token. Unlike the masked
+// Prompt, the secret never gets to Go strings: readline only gets the mask
+// runes, the input is kept in a buffer zeroed after use and returned
+// as bytes. Only typing, Backspace and Ctrl-U editing is supported, history
+// is disabled. Default, Validate, Formatter, OnChange, OnValidate and
+// OnSubmit are not used as they take the input as a string.
+type SecretPrompt struct {
+	BasicPrompt
+
+	// Mask is displayed instead of each input rune, `*` by default
+	Mask rune
+
+	// ValidateSecret is optional. If set, this function is used to validate
+	// the secret on submit, the input is cleared if it is invalid
+	ValidateSecret func(secret []byte) error
+}
+
+// Run runs the prompt, returning the secret. The caller should Wipe
+// the secret after use
+func (sp *SecretPrompt) Run() ([]byte, error) {
+	err := sp.Init()
+	if err != nil {
+		return nil, err
+	}
+	if sp.Mask == 0 {
+		sp.Mask = '*'
+	}
+	// vim normal mode keys move the cursor
+	sp.c.VimMode = false
+	sp.c.DisableAutoSaveHistory = true
+
+	sp.rl, err = readline.NewEx(sp.c)
+	if err != nil {
+		return nil, err
+	}
+	defer sp.rl.Close()
+
+	secret := &secretBuffer{}
+	defer secret.reset()
+
+	bindings := []binding{
+		{actions: []Action{ActionSubmit}, desc: "submit"},
+		{actions: []Action{ActionCancel}, desc: "cancel"},
+	}
+	var errMsg string
+	var setPrompt = func() {
+		footer := sp.keyHelp(bindings...)
+		if errMsg != "" {
+			footer = red("Error: ") + errMsg
+		}
+		sp.rl.SetPrompt(withFooter(sp.Indent+sp.state+" "+sp.PromptInitial(sp.prompt), footer))
+	}
+	setPrompt()
+
+	sp.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		if sp.toggleHelp(r, secret.len() == 0) {
+			setPrompt()
+			return r, false
+		}
+		r, ok := sp.KeyMap.resolve(r, ActionSubmit, ActionCancel)
+		if !ok {
+			return r, false
+		}
+		switch {
+		case r == readline.CharEnter, r == readline.CharCtrlJ,
+			r == readline.CharInterrupt, r == readline.CharDelete:
+			return r, true
+		case r == readline.CharBackspace, r == readline.CharCtrlH:
+			secret.pop()
+		case r == readline.CharCtrlU:
+			secret.reset()
+		case unicode.IsPrint(r):
+			// pasted runes come one by one too, none is echoed
+			secret.push(r)
+			r = sp.Mask
+		default:
+			// other editing keys move the cursor off the line end
+			// or insert killed text
+			return r, false
+		}
+		errMsg = ""
+		sp.state = sp.IconInitial
+		if secret.len() > 0 {
+			sp.state = sp.IconGood
+		}
+		setPrompt()
+		return r, true
+	}
+
+	for {
+		// the line holds only the mask runes
+		_, err = sp.rl.Readline()
+		if err != nil || sp.ValidateSecret == nil {
+			break
+		}
+		b := secret.bytes()
+		verr := sp.ValidateSecret(b)
+		Wipe(b)
+		if verr == nil {
+			break
+		}
+		v, ok := verr.(*ValidationError)
+		if !ok {
+			return nil, verr
+		}
+		errMsg = v.msg
+		sp.state = sp.IconBad
+		secret.reset()
+		setPrompt()
+	}
+
+	if err != nil {
+		switch {
+		case err.Error() == "Interrupt":
+			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
+		}
+		sp.rl.Write([]byte("\n"))
+		return nil, sp.canceled(err)
+	}
+
+	sp.state = sp.IconGood
+	echo := strings.Repeat(string(sp.Mask), secretLen)
+	sp.rl.Write([]byte(sp.Indent + sp.state + " " + sp.prompt + sp.InputResult(echo) + "\n"))
+
+	return secret.bytes(), nil
+}
+
+// Wipe zeroes the secret returned by SecretPrompt
+func Wipe(secret []byte) {
+	for i := range secret {
+		secret[i] = 0
+	}
+}
+
+// secretBuffer holds the secret runes, the memory it drops is zeroed
+type secretBuffer struct {
+	runes []rune
+}
+
+func (b *secretBuffer) len() int {
+	return len(b.runes)
+}
+
+// push appends r, the runes are moved to a bigger buffer
+// if there is no room and the old one is zeroed
+func (b *secretBuffer) push(r rune) {
+	if len(b.runes) == cap(b.runes) {
+		grown := make([]rune, len(b.runes), 2*cap(b.runes)+16)
+		copy(grown, b.runes)
+		b.reset()
+		b.runes = grown
+	}
+	b.runes = append(b.runes, r)
+}
+
+// pop removes the last rune
+func (b *secretBuffer) pop() {
+	if n := len(b.runes); n > 0 {
+		b.runes[n-1] = 0
+		b.runes = b.runes[:n-1]
+	}
+}
+
+// reset zeroes and removes all runes
+func (b *secretBuffer) reset() {
+	for i := range b.runes {
+		b.runes[i] = 0
+	}
+	b.runes = b.runes[:0]
+}
+
+// bytes returns the secret encoded to UTF-8 in a new slice
+func (b *secretBuffer) bytes() []byte {
+	n := 0
+	for _, r := range b.runes {
+		n += utf8.RuneLen(r)
+	}
+	out := make([]byte, n)
+	i := 0
+	for _, r := range b.runes {
+		i += utf8.EncodeRune(out[i:], r)
+	}
+	return out
+}
+
+// AskSecret func is default secret prompt
+func AskSecret(label string) ([]byte, error) {
+	sp := SecretPrompt{
+		BasicPrompt: BasicPrompt{
+			Label: label,
+		},
+	}
+	return sp.Run()
+}
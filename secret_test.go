@@ -0,0 +1,97 @@
+package promptui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func secretTest(input, output string, validate func([]byte) error) func(t *testing.T) {
+	return func(t *testing.T) {
+		in := bytes.Buffer{}
+		out := bytes.Buffer{}
+		sp := SecretPrompt{
+			BasicPrompt: BasicPrompt{
+				Label:  "test",
+				stdin:  &in,
+				stdout: &out,
+			},
+			ValidateSecret: validate,
+		}
+
+		in.Write([]byte(input + "\n"))
+		res, err := sp.Run()
+
+		if err != nil {
+			t.Errorf("error during prompt: %s", err)
+		}
+
+		if string(res) != output {
+			t.Errorf("wrong result: %s != %s", res, output)
+		}
+
+		expected := bold(IconGood) + " test: \033[2m********\033[0m\n"
+		if !bytes.Equal(out.Bytes(), []byte(expected)) {
+			t.Errorf("wrong output: %s != %s", out.Bytes(), expected)
+		}
+	}
+}
+
+func TestSecretPrompt(t *testing.T) {
+	minLen := func(secret []byte) error {
+		if len(secret) < 3 {
+			return NewValidationError("too short")
+		}
+		return nil
+	}
+	t.Run("can read input", secretTest("hi", "hi", nil))
+	t.Run("can read unicode input", secretTest("пароль", "пароль", nil))
+	t.Run("can delete input", secretTest("hx\x7fi", "hi", nil))
+	t.Run("can kill input", secretTest("hx\x15hi", "hi", nil))
+	t.Run("ignores cursor keys", secretTest("h\x02\x01i", "hi", nil))
+	t.Run("clears invalid input", secretTest("hi\nabc", "abc", minLen))
+}
+
+func TestSecretBuffer(t *testing.T) {
+	b := &secretBuffer{}
+	for _, r := range "0123456789abcdef" {
+		b.push(r)
+	}
+	old := b.runes
+	b.push('g')
+	for i, r := range old {
+		if r != 0 {
+			t.Fatalf("old buffer is not zeroed at %d: %q", i, r)
+		}
+	}
+	b.pop()
+	if got := string(b.bytes()); got != "0123456789abcdef" {
+		t.Errorf("wrong secret: %q", got)
+	}
+	runes := b.runes[:cap(b.runes)]
+	b.reset()
+	for i, r := range runes {
+		if r != 0 {
+			t.Fatalf("buffer is not zeroed at %d: %q", i, r)
+		}
+	}
+}
+
+func TestWipe(t *testing.T) {
+	secret := []byte("secret")
+	Wipe(secret)
+	if !bytes.Equal(secret, make([]byte, 6)) {
+		t.Errorf("secret is not zeroed: %q", secret)
+	}
+}
+
+func TestSecretPromptEOF(t *testing.T) {
+	sp := SecretPrompt{
+		BasicPrompt: BasicPrompt{
+			stdin:  &bytes.Buffer{},
+			stdout: &bytes.Buffer{},
+		},
+	}
+	if _, err := sp.Run(); err != ErrEOF {
+		t.Errorf("wrong error: %v != %v", err, ErrEOF)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/karantin2020/readline"
 )
@@ -18,6 +19,54 @@ type Select struct {
 	Items     []string // Items are the items to use in the list.
 	Default   int      // Index of default item starting from 0
 	IsVimMode bool     // Whether readline is using Vim mode.
+
+	// Source is optional. If set, items are requested from it instead of
+	// Items, only the visible window plus Lookahead items are loaded
+	Source ItemSource
+
+	// Size is the number of visible items, 5 by default
+	Size int
+
+	// Lookahead is the number of items to load ahead of the visible window
+	// from LazySource, Size by default
+	Lookahead int
+
+	// Details is optional. If set, it returns multiline details of the
+	// highlighted item, shown in a panel below the list
+	Details func(i int, item string) string
+
+	// DetailsHeight is the number of details panel lines, 3 by default.
+	// Longer details are cut
+	DetailsHeight int
+
+	// Separators are indexes of items shown as group headers,
+	// they can't be selected
+	Separators map[int]bool
+
+	// Disabled maps indexes of items that can't be selected to the reason
+	// shown next to them. Disabled items are rendered faint
+	Disabled map[int]string
+
+	// KeyMap binds keys to actions, EmacsKeyMap or VimKeyMap is used if nil
+	KeyMap KeyMap
+
+	// KeyHelp shows the line with the active key bindings under the list,
+	// the help key hides and shows it
+	KeyHelp bool
+
+	// Handlers are called in order for every key before it moves
+	// the selection. The line is empty, PromptState.Selected returns
+	// the highlighted item. A line returned by a handler is ignored,
+	// the key is consumed
+	Handlers []KeyHandler
+
+	// OnChange is called with the newly highlighted item
+	OnChange func(i int, item string)
+	// OnSubmit is called with the selected item
+	OnSubmit func(i int, item string)
+	// OnCancel is called with the highlighted item index and ErrInterrupt
+	// or ErrEOF when the list is interrupted
+	OnCancel func(i int, err error)
 }
 
 // Run runs the Select list. It returns the index of the selected element,
@@ -26,6 +75,20 @@ func (s *Select) Run() (int, string, error) {
 	return s.innerRun(s.Default, ' ')
 }
 
+func (s *Select) source() ItemSource {
+	if s.Source != nil {
+		return s.Source
+	}
+	return sliceSource(s.Items)
+}
+
+// loadAsync requests items from lazy, done is run in a new goroutine
+// as Load is called under the render lock and a source may call done
+// before Load returns
+func loadAsync(lazy LazySource, i, j int, done func()) {
+	lazy.Load(i, j, func() { go done() })
+}
+
 func (s *Select) innerRun(starting int, top rune) (int, string, error) {
 	stdin := readline.NewCancelableStdin(os.Stdin)
 	c := &readline.Config{}
@@ -36,8 +99,11 @@ func (s *Select) innerRun(starting int, top rune) (int, string, error) {
 
 	c.Stdin = stdin
 
+	var vim *selectVim
 	if s.IsVimMode {
 		c.VimMode = true
+		c.Stdin = &keyStdin{stdin, vimSelectKeys}
+		vim = &selectVim{}
 	}
 
 	prompt := s.Label + ": "
@@ -45,14 +111,56 @@ func (s *Select) innerRun(starting int, top rune) (int, string, error) {
 	c.HistoryLimit = -1
 	c.UniqueEditLine = true
 
-	start := 0
-	end := 4
+	src := s.source()
+	lazy, isLazy := src.(LazySource)
 
-	if len(s.Items) <= end {
-		end = len(s.Items) - 1
+	size := s.Size
+	if size <= 0 {
+		size = 5
+	}
+	lookahead := s.Lookahead
+	if lookahead <= 0 {
+		lookahead = size
+	}
+	// lazy sources grow while loading, so the list height is fixed
+	height := size
+	if !isLazy && src.Len() < height {
+		height = src.Len()
+	}
+	details := 0
+	if s.Details != nil {
+		details = s.DetailsHeight
+		if details <= 0 {
+			details = 3
+		}
 	}
 
+	km := s.KeyMap
+	if km == nil {
+		km = defaultKeyMap(s.IsVimMode)
+	}
+	// footer is the number of lines under the list: the help line
+	// and the line of handlers messages
+	footer := 0
+	showHelp := s.KeyHelp
+	if s.KeyHelp {
+		footer++
+	}
+	if len(s.Handlers) > 0 {
+		footer++
+	}
+	bindings := s.bindings(vim != nil)
+	st := &PromptState{}
+
+	var mu sync.Mutex
+	start := 0
 	selected := starting
+	if !s.selectable(selected) {
+		selected = s.move(src, selected, 1)
+	}
+	if !s.selectable(selected) {
+		selected = s.move(src, selected, -1)
+	}
 
 	rl, err := readline.NewEx(c)
 	if err != nil {
@@ -60,76 +168,174 @@ func (s *Select) innerRun(starting int, top rune) (int, string, error) {
 	}
 
 	rl.Write([]byte(hideCursor))
-	rl.Write([]byte(strings.Repeat("\n", end-start+1)))
+	rl.Write([]byte(strings.Repeat("\n", height+details+footer)))
 
 	counter := 0
 
 	rl.Operation.ExitVimInsertMode() // Never use insert mode for selects
 
-	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
-		if rl.Operation.IsEnableVimMode() {
-			rl.Operation.ExitVimInsertMode()
-			// Remap j and k for down/up selections immediately after an
-			// `i` press
-			switch key {
-			case 'j':
-				key = readline.CharNext
-			case 'k':
-				key = readline.CharPrev
-			}
+	var render func()
+	render = func() {
+		n := src.Len()
+		if selected >= n {
+			selected = n - 1
 		}
-
-		switch key {
-		case readline.CharEnter:
-			return nil, 0, true
-		case readline.CharNext:
-			switch selected {
-			case len(s.Items) - 1:
-			case end:
-				start++
-				end++
-				fallthrough
-			default:
-				selected++
-			}
-		case readline.CharPrev:
-			switch selected {
-			case 0:
-			case start:
+		if selected < 0 {
+			selected = 0
+		}
+		if selected < start {
+			start = selected
+			// keep the group header of the first item visible
+			if height > 1 && start > 0 && s.Separators[start-1] {
 				start--
-				end--
-				fallthrough
-			default:
-				selected--
 			}
 		}
+		if selected >= start+height {
+			start = selected - height + 1
+		}
+		if isLazy {
+			loadAsync(lazy, start, start+height+lookahead, func() {
+				mu.Lock()
+				render()
+				mu.Unlock()
+				rl.Refresh()
+			})
+		}
 
-		list := make([]string, end-start+1)
-		for i := start; i <= end; i++ {
+		list := make([]string, height)
+		for r := range list {
+			i := start + r
+			if i >= n {
+				list[r] = clearLine + "\r"
+				continue
+			}
 			page := ' '
 			selection := " "
-			item := s.Items[i]
+			item := src.At(i)
 
 			switch i {
 			case 0:
 				page = top
-			case len(s.Items) - 1:
+			case n - 1:
 			case start:
 				page = ' '
-			case end:
+			case start + height - 1:
 				page = ' '
 			}
 			if i == selected {
 				selection = IconQuest
 				item = blue(item)
 			}
-			list[i-start] = clearLine + "\r" + string(page) + " " + selection + " " + item
+			if s.Separators[i] {
+				selection = " "
+				item = bold(item)
+			} else if reason, ok := s.Disabled[i]; ok {
+				if reason != "" {
+					item += " (" + reason + ")"
+				}
+				item = faint(item)
+			}
+			list[r] = clearLine + "\r" + string(page) + " " + selection + " " + item
+		}
+		if details > 0 {
+			list = append(list, s.details(src, selected, details)...)
+		}
+		if s.KeyHelp {
+			line := clearLine + "\r"
+			if showHelp {
+				line += "  " + faint(km.help(bindings...))
+			}
+			list = append(list, line)
+		}
+		if len(s.Handlers) > 0 {
+			list = append(list, clearLine+"\r  "+st.message)
 		}
 
+		header := st.iconOr(bold(IconInitial)) + " " + bold(prompt)
+		if vim != nil && vim.searching {
+			header += "/" + string(vim.query)
+		}
 		prefix := ""
 		prefix += upLine(uint(len(list))) + "\r" + clearLine
-		p := prefix + bold(IconInitial) + " " + bold(prompt) + downLine(1) + strings.Join(list, downLine(1))
+		p := prefix + header + downLine(1) + strings.Join(list, downLine(1))
 		rl.SetPrompt(p)
+	}
+
+	half := height / 2
+	if half < 1 {
+		half = 1
+	}
+
+	c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if s.KeyHelp && km.Is(r, ActionHelp) && (vim == nil || !vim.searching) {
+			mu.Lock()
+			showHelp = !showHelp
+			render()
+			mu.Unlock()
+			return r, false
+		}
+		r, ok := km.resolve(r, ActionSubmit, ActionCancel, ActionNext, ActionPrev)
+		if !ok {
+			return r, false
+		}
+		if len(s.Handlers) > 0 {
+			mu.Lock()
+			st.selected = selected
+			var replaced bool
+			r, _, _, replaced = st.handle(s.Handlers, nil, 0, r)
+			render()
+			mu.Unlock()
+			if replaced && st.action == "" {
+				return r, false
+			}
+		}
+		if vim != nil && st.action == "" {
+			mu.Lock()
+			var pass bool
+			prev := selected
+			selected, r, pass = vim.handle(s, src, selected, half, r)
+			if selected != prev && s.OnChange != nil {
+				s.OnChange(selected, src.At(selected))
+			}
+			if !pass {
+				render()
+			}
+			mu.Unlock()
+			if !pass {
+				return r, false
+			}
+		}
+		if r == readline.CharEnter {
+			mu.Lock()
+			defer mu.Unlock()
+			return r, s.selectable(selected) && (!isLazy || lazy.Ready(selected))
+		}
+		return r, true
+	}
+
+	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		if rl.Operation.IsEnableVimMode() {
+			// vim keys are handled before readline, see selectVim
+			rl.Operation.ExitVimInsertMode()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		prev := selected
+		switch key {
+		case readline.CharEnter:
+			return nil, 0, true
+		case readline.CharNext:
+			selected = s.move(src, selected, 1)
+		case readline.CharPrev:
+			selected = s.move(src, selected, -1)
+		}
+		if selected != prev && s.OnChange != nil {
+			s.OnChange(selected, src.At(selected))
+		}
+
+		render()
 		rl.Refresh()
 
 		counter++
@@ -151,19 +357,90 @@ func (s *Select) innerRun(starting int, top rune) (int, string, error) {
 		rl.Write([]byte("\n"))
 		rl.Write([]byte(showCursor))
 		rl.Refresh()
+		if s.OnCancel != nil && (err == ErrInterrupt || err == ErrEOF) {
+			mu.Lock()
+			i := selected
+			mu.Unlock()
+			s.OnCancel(i, err)
+		}
 		return 0, "", err
 	}
 
-	rl.Write(bytes.Repeat([]byte(clearLine+upLine(1)), end-start+1))
+	rl.Write(bytes.Repeat([]byte(clearLine+upLine(1)), height+details+footer))
 	rl.Write([]byte("\r"))
 
-	out := s.Items[selected]
+	mu.Lock()
+	out := src.At(selected)
+	mu.Unlock()
+	if s.OnSubmit != nil {
+		s.OnSubmit(selected, out)
+	}
 	rl.Write([]byte(IconGood + " " + prompt + faint(out) + "\n"))
 
 	rl.Write([]byte(showCursor))
 	return selected, out, err
 }
 
+// bindings returns key bindings of the list listed in the help line
+func (s *Select) bindings(vim bool) []binding {
+	move := binding{actions: []Action{ActionPrev, ActionNext}, desc: "move"}
+	cancel := binding{actions: []Action{ActionCancel}, desc: "cancel"}
+	out := []binding{move}
+	if vim {
+		move.keys = []string{"k", "j"}
+		cancel.keys = []string{"q", "Esc"}
+		out = []binding{
+			move,
+			{keys: []string{"gg", "G"}, desc: "jump"},
+			{keys: []string{"Ctrl-U", "Ctrl-D"}, desc: "scroll"},
+			{keys: []string{"/", "n", "N"}, desc: "search"},
+		}
+	}
+	return append(out,
+		binding{actions: []Action{ActionSubmit}, desc: "select"},
+		cancel,
+		binding{actions: []Action{ActionHelp}, desc: "hide help"})
+}
+
+// selectable reports if the item at i is not a separator or disabled
+func (s *Select) selectable(i int) bool {
+	if s.Separators[i] {
+		return false
+	}
+	_, disabled := s.Disabled[i]
+	return !disabled
+}
+
+// move returns the index of the next selectable item in step direction,
+// from is returned if there is no such item
+func (s *Select) move(src ItemSource, from, step int) int {
+	for i := from + step; i >= 0 && i < src.Len(); i += step {
+		if s.selectable(i) {
+			return i
+		}
+	}
+	return from
+}
+
+// details returns exactly n lines of the selected item details panel
+func (s *Select) details(src ItemSource, selected, n int) []string {
+	var lines []string
+	if lazy, ok := src.(LazySource); selected < src.Len() && (!ok || lazy.Ready(selected)) {
+		lines = strings.Split(strings.TrimRight(s.Details(selected, src.At(selected)), "\n"), "\n")
+	}
+	if len(lines) > n {
+		lines = append(lines[:n-1], "…")
+	}
+	out := make([]string, n)
+	for i := range out {
+		out[i] = clearLine + "\r"
+		if i < len(lines) {
+			out[i] += "    " + lines[i]
+		}
+	}
+	return out
+}
+
 // SelectWithAdd represents a list for selecting a single item, or selecting
 // a newly created item.
 type SelectWithAdd struct {
@@ -0,0 +1,141 @@
+package promptui
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSelectDetails(t *testing.T) {
+	s := Select{
+		Details: func(i int, item string) string {
+			return item + "\nline 2\nline 3\nline 4\n"
+		},
+	}
+	src := sliceSource{"one", "two"}
+
+	t.Run("cuts long details", func(t *testing.T) {
+		expected := []string{
+			clearLine + "\r    two",
+			clearLine + "\r    line 2",
+			clearLine + "\r    …",
+		}
+		if got := s.details(src, 1, 3); !reflect.DeepEqual(got, expected) {
+			t.Errorf("wrong details: %q != %q", got, expected)
+		}
+	})
+
+	t.Run("pads short details", func(t *testing.T) {
+		got := s.details(src, 0, 6)
+		if len(got) != 6 || got[5] != clearLine+"\r" {
+			t.Errorf("wrong details: %q", got)
+		}
+	})
+}
+
+func TestSelectMove(t *testing.T) {
+	s := Select{
+		Separators: map[int]bool{0: true, 3: true},
+		Disabled:   map[int]string{2: "no capacity", 5: ""},
+	}
+	src := sliceSource{"Europe", "eu-west-1", "eu-north-1", "America", "us-east-1", "us-west-1"}
+
+	cases := []struct {
+		from, step, expected int
+	}{
+		{1, 1, 4},
+		{4, -1, 1},
+		{4, 1, 4},
+		{1, -1, 1},
+	}
+	for _, c := range cases {
+		if got := s.move(src, c.from, c.step); got != c.expected {
+			t.Errorf("wrong move from %d by %d: %d != %d", c.from, c.step, got, c.expected)
+		}
+	}
+}
+
+func TestSelectVim(t *testing.T) {
+	s := Select{Separators: map[int]bool{0: true}}
+	src := sliceSource{"group", "alpha", "beta", "gamma", "delta", "beta2", "omega"}
+
+	keys := func(v *selectVim, selected int, in string) (int, rune, bool) {
+		var (
+			out  rune
+			pass bool
+		)
+		for _, k := range in {
+			selected, out, pass = v.handle(&s, src, selected, 2, k)
+		}
+		return selected, out, pass
+	}
+
+	cases := []struct {
+		name     string
+		from     int
+		keys     string
+		expected int
+	}{
+		{"moves with counts", 1, "3j", 4},
+		{"goes to the last item", 1, "G", 6},
+		{"goes to the first selectable item", 5, "gg", 1},
+		{"goes to the counted item", 1, "4gg", 3},
+		{"moves half page", 1, "\x04", 3},
+		{"moves half page up", 6, "\x15", 4},
+		{"searches forward", 1, "/BET\r", 2},
+		{"jumps to next match", 1, "/beta\rn", 5},
+		{"jumps to previous match", 2, "/beta\rN", 2},
+		{"wraps around searching", 5, "/alpha\r", 1},
+	}
+	for _, c := range cases {
+		got, _, _ := keys(&selectVim{}, c.from, c.keys)
+		if got != c.expected {
+			t.Errorf("%s: wrong selection %d != %d", c.name, got, c.expected)
+		}
+	}
+
+	for _, k := range []string{"q", "\x1b"} {
+		if _, out, pass := keys(&selectVim{}, 1, k); !pass || out != '\x03' {
+			t.Errorf("%q does not cancel", k)
+		}
+	}
+
+	// EOF passes even while searching
+	if _, out, pass := keys(&selectVim{}, 1, "/\x00"); !pass || out != 0 {
+		t.Error("EOF does not pass")
+	}
+}
+
+// syncSource is LazySource loading all items before Load returns
+type syncSource struct {
+	sliceSource
+	loaded bool
+}
+
+func (s *syncSource) Ready(i int) bool { return s.loaded }
+
+func (s *syncSource) Load(i, j int, done func()) {
+	if !s.loaded {
+		s.loaded = true
+		done()
+	}
+}
+
+func TestSelectLoadSync(t *testing.T) {
+	var mu sync.Mutex
+	rendered := make(chan struct{})
+	mu.Lock()
+	loadAsync(&syncSource{sliceSource: sliceSource{"one"}}, 0, 5, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		close(rendered)
+	})
+	mu.Unlock()
+
+	select {
+	case <-rendered:
+	case <-time.After(time.Second):
+		t.Error("done is not called")
+	}
+}
@@ -0,0 +1,159 @@
+package promptui
+
+import (
+	"strings"
+
+	"github.com/karantin2020/readline"
+)
+
+// vimSelectKeys translates arrows, which readline passes as raw escape
+// sequences in vim mode, so a single Esc can be told apart
+var vimSelectKeys = map[string]byte{
+	"\x1b[A": readline.CharPrev,
+	"\x1b[B": readline.CharNext,
+	"\x1bOA": readline.CharPrev,
+	"\x1bOB": readline.CharNext,
+}
+
+// selectVim handles modal vim navigation of Select: counts, `gg`/`G`,
+// Ctrl-d/Ctrl-u half page, `/` search with `n`/`N` and `q`/Esc to cancel
+type selectVim struct {
+	count     int
+	pending   rune
+	searching bool
+	query     []rune
+}
+
+// handle applies the key to the selection. It returns the new selection,
+// the key to pass to readline and true if it must be passed
+func (v *selectVim) handle(s *Select, src ItemSource, selected, half int, key rune) (int, rune, bool) {
+	if key == 0 {
+		// stdin is closed, readline handles io.EOF
+		return selected, key, true
+	}
+	if v.searching {
+		switch key {
+		case readline.CharEnter:
+			v.searching = false
+			selected = v.find(s, src, selected, 1)
+		case readline.CharEsc:
+			v.searching = false
+			v.query = v.query[:0]
+		case readline.CharBackspace, readline.CharCtrlH:
+			if len(v.query) > 0 {
+				v.query = v.query[:len(v.query)-1]
+			}
+		case readline.CharInterrupt:
+			return selected, key, true
+		default:
+			if readline.IsPrintable(key) {
+				v.query = append(v.query, key)
+			}
+		}
+		return selected, key, false
+	}
+
+	if key >= '1' && key <= '9' || key == '0' && v.count > 0 {
+		v.count = v.count*10 + int(key-'0')
+		return selected, key, false
+	}
+	counted := v.count > 0
+	n := v.count
+	if n == 0 {
+		n = 1
+	}
+	v.count = 0
+
+	if v.pending == 'g' {
+		v.pending = 0
+		if key == 'g' {
+			if counted {
+				return v.jumpFrom(s, src, selected, n-1), key, false
+			}
+			return v.jumpFrom(s, src, selected, 0), key, false
+		}
+	}
+
+	switch key {
+	case 'j', readline.CharNext:
+		for ; n > 0; n-- {
+			selected = s.move(src, selected, 1)
+		}
+	case 'k', readline.CharPrev:
+		for ; n > 0; n-- {
+			selected = s.move(src, selected, -1)
+		}
+	case 'g':
+		v.pending = 'g'
+		if counted {
+			v.count = n
+		}
+	case 'G':
+		if counted {
+			return v.jumpFrom(s, src, selected, n-1), key, false
+		}
+		selected = v.jumpFrom(s, src, selected, src.Len()-1)
+	case readline.CharDelete: // Ctrl-d
+		selected = v.jumpFrom(s, src, selected, selected+half*n)
+	case readline.CharCtrlU:
+		selected = v.jumpFrom(s, src, selected, selected-half*n)
+	case '/':
+		v.searching = true
+		v.query = v.query[:0]
+	case 'n':
+		for ; n > 0; n-- {
+			selected = v.find(s, src, selected, 1)
+		}
+	case 'N':
+		for ; n > 0; n-- {
+			selected = v.find(s, src, selected, -1)
+		}
+	case 'q', readline.CharEsc:
+		return selected, readline.CharInterrupt, true
+	case readline.CharEnter, readline.CharInterrupt:
+		return selected, key, true
+	}
+	return selected, key, false
+}
+
+// jumpFrom returns the selectable item nearest to i in the direction
+// from the current selection, the selection is kept if there is no such item
+func (v *selectVim) jumpFrom(s *Select, src ItemSource, selected, i int) int {
+	if i >= src.Len() {
+		i = src.Len() - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	if s.selectable(i) {
+		return i
+	}
+	step := 1
+	if i < selected {
+		step = -1
+	}
+	if j := s.move(src, i, step); j != i {
+		return j
+	}
+	if j := s.move(src, i, -step); j != i {
+		return j
+	}
+	return selected
+}
+
+// find returns the next selectable item matching the search query
+// in step direction, wrapping around the list
+func (v *selectVim) find(s *Select, src ItemSource, selected, step int) int {
+	if len(v.query) == 0 {
+		return selected
+	}
+	query := strings.ToLower(string(v.query))
+	n := src.Len()
+	for k := 1; k <= n; k++ {
+		i := ((selected+step*k)%n + n) % n
+		if s.selectable(i) && strings.Contains(strings.ToLower(src.At(i)), query) {
+			return i
+		}
+	}
+	return selected
+}
@@ -0,0 +1,103 @@
+package promptui
+
+import "sync"
+
+// ItemSource provides Select items on demand, so only visible items
+// are requested
+type ItemSource interface {
+	// Len returns the number of items
+	Len() int
+	// At returns the item at index i
+	At(i int) string
+}
+
+// LazySource is ItemSource that loads items in background.
+// Len may grow as items are loaded, items that are not loaded yet
+// are shown as loading rows and can't be selected
+type LazySource interface {
+	ItemSource
+	// Load requests items from i to j (exclusive) to be loaded,
+	// done is called from any goroutine when new items arrive,
+	// it may be called before Load returns
+	Load(i, j int, done func())
+	// Ready reports if the item at i is loaded
+	Ready(i int) bool
+}
+
+// sliceSource is ItemSource of materialized items
+type sliceSource []string
+
+func (s sliceSource) Len() int        { return len(s) }
+func (s sliceSource) At(i int) string { return s[i] }
+
+// PagedSource is LazySource fetching pages of items with cursors,
+// pages are fetched one by one while the visible window needs them
+type PagedSource struct {
+	// Fetch loads the page after the cursor, the first page cursor is "".
+	// It returns the next page cursor, "" if there are no more pages
+	Fetch func(cursor string) (items []string, next string, err error)
+
+	mu      sync.Mutex
+	items   []string
+	next    string
+	done    bool
+	loading bool
+	err     error
+}
+
+// Len returns the number of loaded items, plus one loading row
+// if there are more pages
+func (ps *PagedSource) Len() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.done && ps.err == nil {
+		return len(ps.items)
+	}
+	return len(ps.items) + 1
+}
+
+// At returns the loaded item, the loading row text or the fetch error
+func (ps *PagedSource) At(i int) string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if i < len(ps.items) {
+		return ps.items[i]
+	}
+	if ps.err != nil {
+		return red("Error: ") + ps.err.Error()
+	}
+	return faint("loading...")
+}
+
+// Ready reports if the item at i is loaded
+func (ps *PagedSource) Ready(i int) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return i < len(ps.items)
+}
+
+// Load fetches the next page if items up to j are not loaded yet
+func (ps *PagedSource) Load(i, j int, done func()) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.done || ps.loading || j <= len(ps.items) {
+		return
+	}
+	ps.loading = true
+	cursor := ps.next
+	go func() {
+		items, next, err := ps.Fetch(cursor)
+		ps.mu.Lock()
+		ps.loading = false
+		if err != nil {
+			ps.err = err
+			ps.done = true
+		} else {
+			ps.items = append(ps.items, items...)
+			ps.next = next
+			ps.done = next == ""
+		}
+		ps.mu.Unlock()
+		done()
+	}()
+}
@@ -0,0 +1,47 @@
+package promptui
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestPagedSource(t *testing.T) {
+	fetched := []string{}
+	ps := &PagedSource{
+		Fetch: func(cursor string) ([]string, string, error) {
+			fetched = append(fetched, cursor)
+			page, _ := strconv.Atoi(cursor)
+			items := []string{"item" + strconv.Itoa(page*2), "item" + strconv.Itoa(page*2+1)}
+			next := strconv.Itoa(page + 1)
+			if page == 1 {
+				next = ""
+			}
+			return items, next, nil
+		},
+	}
+
+	load := func(i, j int) {
+		done := make(chan struct{})
+		ps.Load(i, j, func() { close(done) })
+		<-done
+	}
+
+	if ps.Len() != 1 || ps.Ready(0) {
+		t.Fatalf("expected only loading row, got %d items", ps.Len())
+	}
+
+	load(0, 2)
+	if ps.Len() != 3 || !ps.Ready(1) || ps.Ready(2) {
+		t.Errorf("wrong state after first page: %d items", ps.Len())
+	}
+
+	load(0, 4)
+	if ps.Len() != 4 || ps.At(3) != "item3" {
+		t.Errorf("wrong state after last page: %d items", ps.Len())
+	}
+
+	ps.Load(0, 10, func() { t.Error("unexpected fetch after last page") })
+	if len(fetched) != 2 || fetched[1] != "1" {
+		t.Errorf("wrong cursors: %v", fetched)
+	}
+}
@@ -5,6 +5,7 @@ var (
 	faint      = Styler(FGFaint)
 	underlined = Styler(FGUnderline)
 	blue       = Styler(FGBlue)
+	reversed   = Styler(reverse)
 )
 
 // Icons used for displaying prompts or status
@@ -17,3 +18,12 @@ var (
 )
 
 var red = Styler(FGBold, FGRed)
+
+// Guides used for displaying TreeSelect nodes
+var (
+	treeBranch    = "|-"
+	treeLast      = "`-"
+	treeLine      = "| "
+	treeExpanded  = "-"
+	treeCollapsed = "+"
+)
@@ -0,0 +1,271 @@
+package promptui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/karantin2020/readline"
+)
+
+// keySubmit is the key textarea stdin produces for Ctrl-D and Alt-Enter.
+// It is Ctrl-\, which readline passes through untouched
+const keySubmit = 0x1c
+
+// submitKeys translates raw Ctrl-D and Alt-Enter (Esc Enter) into keySubmit,
+// so they can't be mixed with Delete key and Enter decoded by readline
+var submitKeys = map[string]byte{
+	"\x04":   keySubmit,
+	"\x1b\r": keySubmit,
+}
+
+// keyStdin translates raw key sequences into single keys
+// before readline decodes them
+type keyStdin struct {
+	io.ReadCloser
+	keys map[string]byte
+}
+
+func (s *keyStdin) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	j := 0
+	for i := 0; i < n; i++ {
+		p[j] = p[i]
+		for seq, k := range s.keys {
+			if bytes.HasPrefix(p[i:n], []byte(seq)) {
+				p[j] = k
+				i += len(seq) - 1
+				break
+			}
+		}
+		j++
+	}
+	return j, err
+}
+
+// textArea is the multiline edit buffer with cursor and vertical scroll
+type textArea struct {
+	lines  [][]rune
+	row    int
+	col    int
+	top    int
+	height int
+}
+
+func newTextArea(s string, height int) *textArea {
+	ta := &textArea{height: height}
+	for _, l := range strings.Split(s, "\n") {
+		ta.lines = append(ta.lines, []rune(l))
+	}
+	ta.row = len(ta.lines) - 1
+	ta.col = len(ta.lines[ta.row])
+	ta.scroll()
+	return ta
+}
+
+// String returns the buffer text
+func (ta *textArea) String() string {
+	ls := make([]string, len(ta.lines))
+	for i, l := range ta.lines {
+		ls[i] = string(l)
+	}
+	return strings.Join(ls, "\n")
+}
+
+// handle applies the key to the buffer, returns false for unknown keys
+func (ta *textArea) handle(key rune) bool {
+	switch key {
+	case readline.CharEnter, readline.CharCtrlJ:
+		ta.newline()
+	case readline.CharBackspace, readline.CharCtrlH:
+		ta.backspace()
+	case readline.CharDelete:
+		ta.delete()
+	case readline.CharBackward:
+		ta.left()
+	case readline.CharForward:
+		ta.right()
+	case readline.CharPrev:
+		ta.up()
+	case readline.CharNext:
+		ta.down()
+	case readline.CharLineStart:
+		ta.col = 0
+	case readline.CharLineEnd:
+		ta.col = len(ta.lines[ta.row])
+	case readline.CharKill:
+		ta.lines[ta.row] = ta.lines[ta.row][:ta.col]
+	case readline.CharTab:
+		for i := 0; i < readline.TabWidth; i++ {
+			ta.insert(' ')
+		}
+	default:
+		if !readline.IsPrintable(key) {
+			return false
+		}
+		ta.insert(key)
+	}
+	ta.scroll()
+	return true
+}
+
+func (ta *textArea) insert(r rune) {
+	l := ta.lines[ta.row]
+	l = append(l[:ta.col], append([]rune{r}, l[ta.col:]...)...)
+	ta.lines[ta.row] = l
+	ta.col++
+}
+
+func (ta *textArea) newline() {
+	l := ta.lines[ta.row]
+	tail := append([]rune{}, l[ta.col:]...)
+	ta.lines[ta.row] = l[:ta.col]
+	ta.lines = append(ta.lines[:ta.row+1], append([][]rune{tail}, ta.lines[ta.row+1:]...)...)
+	ta.row++
+	ta.col = 0
+}
+
+func (ta *textArea) backspace() {
+	if ta.col > 0 {
+		l := ta.lines[ta.row]
+		ta.lines[ta.row] = append(l[:ta.col-1], l[ta.col:]...)
+		ta.col--
+		return
+	}
+	if ta.row == 0 {
+		return
+	}
+	prev := ta.lines[ta.row-1]
+	ta.col = len(prev)
+	ta.lines[ta.row-1] = append(prev, ta.lines[ta.row]...)
+	ta.lines = append(ta.lines[:ta.row], ta.lines[ta.row+1:]...)
+	ta.row--
+}
+
+func (ta *textArea) delete() {
+	l := ta.lines[ta.row]
+	if ta.col < len(l) {
+		ta.lines[ta.row] = append(l[:ta.col], l[ta.col+1:]...)
+		return
+	}
+	if ta.row == len(ta.lines)-1 {
+		return
+	}
+	ta.lines[ta.row] = append(l, ta.lines[ta.row+1]...)
+	ta.lines = append(ta.lines[:ta.row+1], ta.lines[ta.row+2:]...)
+}
+
+func (ta *textArea) left() {
+	if ta.col > 0 {
+		ta.col--
+	} else if ta.row > 0 {
+		ta.row--
+		ta.col = len(ta.lines[ta.row])
+	}
+}
+
+func (ta *textArea) right() {
+	if ta.col < len(ta.lines[ta.row]) {
+		ta.col++
+	} else if ta.row < len(ta.lines)-1 {
+		ta.row++
+		ta.col = 0
+	}
+}
+
+func (ta *textArea) up() {
+	if ta.row > 0 {
+		ta.row--
+		ta.clampCol()
+	}
+}
+
+func (ta *textArea) down() {
+	if ta.row < len(ta.lines)-1 {
+		ta.row++
+		ta.clampCol()
+	}
+}
+
+func (ta *textArea) clampCol() {
+	if ta.col > len(ta.lines[ta.row]) {
+		ta.col = len(ta.lines[ta.row])
+	}
+}
+
+// scroll keeps the cursor row inside the visible window
+func (ta *textArea) scroll() {
+	if ta.row < ta.top {
+		ta.top = ta.row
+	}
+	if ta.row >= ta.top+ta.height {
+		ta.top = ta.row - ta.height + 1
+	}
+	if max := len(ta.lines) - ta.height; ta.top > max {
+		ta.top = max
+	}
+	if ta.top < 0 {
+		ta.top = 0
+	}
+}
+
+// visible returns the range of visible lines
+func (ta *textArea) visible() (int, int) {
+	end := ta.top + ta.height
+	if end > len(ta.lines) {
+		end = len(ta.lines)
+	}
+	return ta.top, end
+}
+
+// render returns visible lines with gutter and fake cursor, gutter of
+// lines in bad is marked red. Lines are cut to width runes,
+// the cursor line is scrolled horizontally
+func (ta *textArea) render(numbers bool, width int, style StyleFn, bad map[int]string) []string {
+	start, end := ta.visible()
+	digits := len(fmt.Sprint(len(ta.lines)))
+	out := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		gutter := "  "
+		if numbers {
+			gutter = fmt.Sprintf("%*d ", digits, i+1)
+		}
+		mark := faint
+		if _, ok := bad[i]; ok {
+			mark = red
+			if !numbers {
+				gutter = "! "
+			}
+		}
+		avail := width - len(gutter) - 1
+		l := ta.lines[i]
+		from := 0
+		if i == ta.row && ta.col >= avail && avail > 0 {
+			from = ta.col - avail + 1
+		}
+		l = l[from:]
+		cut := false
+		if avail > 0 && len(l) > avail {
+			l = l[:avail]
+			cut = true
+		}
+		text := style(string(l))
+		if i == ta.row {
+			text = cursorLine(l, ta.col-from, style)
+		}
+		if cut {
+			text += faint("…")
+		}
+		out = append(out, mark(gutter)+text)
+	}
+	return out
+}
+
+// cursorLine returns the styled line with fake cursor at c
+func cursorLine(l []rune, c int, style StyleFn) string {
+	if c < len(l) {
+		return style(string(l[:c])) + reversed(string(l[c])) + style(string(l[c+1:]))
+	}
+	return style(string(l)) + reversed(" ")
+}
@@ -0,0 +1,85 @@
+package promptui
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/karantin2020/readline"
+)
+
+func typeKeys(ta *textArea, keys ...rune) {
+	for _, k := range keys {
+		ta.handle(k)
+	}
+}
+
+func TestTextArea(t *testing.T) {
+	t.Run("seeds the buffer with cursor at the end", func(t *testing.T) {
+		ta := newTextArea("one\ntwo", 10)
+		if ta.row != 1 || ta.col != 3 {
+			t.Errorf("wrong cursor: %d:%d", ta.row, ta.col)
+		}
+	})
+
+	t.Run("edits across lines", func(t *testing.T) {
+		ta := newTextArea("", 10)
+		typeKeys(ta, 'a', 'b', readline.CharEnter, readline.CharEnter, 'c')
+		typeKeys(ta, readline.CharPrev, readline.CharPrev, readline.CharLineEnd, '!')
+		expected := "ab!\n\nc"
+		if s := ta.String(); s != expected {
+			t.Errorf("wrong text: %q != %q", s, expected)
+		}
+	})
+
+	t.Run("joins lines on backspace and delete", func(t *testing.T) {
+		ta := newTextArea("ab\ncd\nef", 10)
+		typeKeys(ta, readline.CharPrev, readline.CharLineStart, readline.CharBackspace)
+		typeKeys(ta, readline.CharLineEnd, readline.CharDelete)
+		expected := "abcdef"
+		if s := ta.String(); s != expected {
+			t.Errorf("wrong text: %q != %q", s, expected)
+		}
+	})
+
+	t.Run("clamps column moving to a shorter line", func(t *testing.T) {
+		ta := newTextArea("a\nlong line", 10)
+		typeKeys(ta, readline.CharPrev)
+		if ta.col != 1 {
+			t.Errorf("wrong column: %d", ta.col)
+		}
+	})
+
+	t.Run("scrolls to keep the cursor visible", func(t *testing.T) {
+		ta := newTextArea("1\n2\n3\n4\n5", 2)
+		if start, end := ta.visible(); start != 3 || end != 5 {
+			t.Errorf("wrong window: %d-%d", start, end)
+		}
+		typeKeys(ta, readline.CharPrev, readline.CharPrev, readline.CharPrev)
+		if start, end := ta.visible(); start != 1 || end != 3 {
+			t.Errorf("wrong window: %d-%d", start, end)
+		}
+	})
+}
+
+func TestKeyStdin(t *testing.T) {
+	in := []byte("a\x04b\x1b\rc\x1b[3~")
+	r := &keyStdin{ioutil.NopCloser(bytes.NewReader(in)), submitKeys}
+	out, _ := ioutil.ReadAll(r)
+	expected := []byte("a\x1cb\x1cc\x1b[3~")
+	if !bytes.Equal(out, expected) {
+		t.Errorf("wrong input: %q != %q", out, expected)
+	}
+}
+
+func TestTextAreaRender(t *testing.T) {
+	ta := newTextArea("ok\ntoo long", 10)
+	plain := func(s string) string { return s }
+	lines := ta.render(true, 80, plain, map[int]string{1: "too long"})
+	if expected := faint("1 ") + "ok"; lines[0] != expected {
+		t.Errorf("wrong line: %q != %q", lines[0], expected)
+	}
+	if expected := red("2 ") + "too long" + reversed(" "); lines[1] != expected {
+		t.Errorf("wrong marked line: %q != %q", lines[1], expected)
+	}
+}
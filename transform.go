@@ -0,0 +1,135 @@
+package promptui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Transform shapes the input line while typing. It returns the new line
+// and cursor position
+type Transform func(line []rune, pos int) ([]rune, int)
+
+// UpperCase transform forces upper case of the input
+func UpperCase(line []rune, pos int) ([]rune, int) {
+	out := make([]rune, len(line))
+	for i, r := range line {
+		out[i] = unicode.ToUpper(r)
+	}
+	return out, pos
+}
+
+// LowerCase transform forces lower case of the input
+func LowerCase(line []rune, pos int) ([]rune, int) {
+	out := make([]rune, len(line))
+	for i, r := range line {
+		out[i] = unicode.ToLower(r)
+	}
+	return out, pos
+}
+
+// StripRunes returns the transform removing runes contained in chars
+func StripRunes(chars string) Transform {
+	return KeepRunes(func(r rune) bool { return !strings.ContainsRune(chars, r) })
+}
+
+// KeepRunes returns the transform removing runes not allowed by f
+func KeepRunes(f func(rune) bool) Transform {
+	return func(line []rune, pos int) ([]rune, int) {
+		out := make([]rune, 0, len(line))
+		newPos := 0
+		for i, r := range line {
+			if !f(r) {
+				continue
+			}
+			out = append(out, r)
+			if i < pos {
+				newPos++
+			}
+		}
+		return out, newPos
+	}
+}
+
+// MaxLength returns the transform cutting the input to n runes
+func MaxLength(n int) Transform {
+	return func(line []rune, pos int) ([]rune, int) {
+		if len(line) <= n {
+			return line, pos
+		}
+		// drop the runes just typed before the cursor
+		over := len(line) - n
+		if pos >= over {
+			line = append(append([]rune{}, line[:pos-over]...), line[pos:]...)
+			return line, pos - over
+		}
+		// the line is too long before the cursor, it is cut
+		if pos > n {
+			pos = n
+		}
+		return line[:n], pos
+	}
+}
+
+// maskSlot reports if the mask rune is a placeholder and if r fits it.
+// `9` is a digit, `A` is a letter and `X` is a letter or a digit
+func maskSlot(m, r rune) (slot, fits bool) {
+	switch m {
+	case '9':
+		return true, unicode.IsDigit(r)
+	case 'A':
+		return true, unicode.IsLetter(r)
+	case 'X':
+		return true, unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return false, false
+}
+
+// applyMask places letters and digits of the line into the mask
+// placeholders in order, runes not fitting their placeholder are dropped.
+// Literals are inserted before each filled placeholder, so deleting
+// the last input rune deletes literals before it
+func applyMask(mask, line []rune, pos int) ([]rune, int) {
+	out := make([]rune, 0, len(mask))
+	newPos := 0
+	m := 0
+	for i, r := range line {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		var literals []rune
+		j := m
+		for ; j < len(mask); j++ {
+			if slot, _ := maskSlot(mask[j], r); slot {
+				break
+			}
+			literals = append(literals, mask[j])
+		}
+		if j == len(mask) {
+			break
+		}
+		if _, fits := maskSlot(mask[j], r); !fits {
+			continue
+		}
+		out = append(append(out, literals...), r)
+		m = j + 1
+		if i < pos {
+			newPos = len(out)
+		}
+	}
+	return out, newPos
+}
+
+// maskRest returns the unfilled part of the mask with `_` placeholders
+func maskRest(mask []rune, filled int) string {
+	if filled >= len(mask) {
+		return ""
+	}
+	rest := []rune{}
+	for _, m := range mask[filled:] {
+		if slot, _ := maskSlot(m, 0); slot {
+			m = '_'
+		}
+		rest = append(rest, m)
+	}
+	return string(rest)
+}
@@ -0,0 +1,58 @@
+package promptui
+
+import "testing"
+
+func TestApplyMask(t *testing.T) {
+	cases := []struct {
+		name, mask, in string
+		pos            int
+		out            string
+		outPos         int
+	}{
+		{"inserts leading literals", "(999) 999-9999", "5", 1, "(5", 2},
+		{"inserts literals before input", "(999) 999-9999", "(5551", 5, "(555) 1", 7},
+		{"drops trailing literals", "(999) 999-9999", "(555) ", 6, "(555", 4},
+		{"drops runes not fitting", "(999) 999-9999", "(55a", 4, "(55", 3},
+		{"keeps cursor", "(999) 999-9999", "(59) 5", 3, "(595", 3},
+		{"cuts to mask length", "XXXX-XXXX", "ab12cd34ef", 10, "ab12-cd34", 9},
+		{"letters only", "AA-99", "1ab23", 5, "ab-23", 5},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, pos := applyMask([]rune(c.mask), []rune(c.in), c.pos)
+			if string(out) != c.out || pos != c.outPos {
+				t.Errorf("wrong result: %s %d != %s %d", string(out), pos, c.out, c.outPos)
+			}
+		})
+	}
+
+	if rest := maskRest([]rune("(999) 999-9999"), 4); rest != ") ___-____" {
+		t.Errorf("wrong mask rest: %s != %s", rest, ") ___-____")
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	cases := []struct {
+		name   string
+		tr     Transform
+		in     string
+		pos    int
+		out    string
+		outPos int
+	}{
+		{"upper case", UpperCase, "abC", 2, "ABC", 2},
+		{"lower case", LowerCase, "AbC", 3, "abc", 3},
+		{"strip runes", StripRunes(" -"), "a -b", 3, "ab", 1},
+		{"max length at end", MaxLength(3), "abcd", 4, "abc", 3},
+		{"max length in the middle", MaxLength(3), "abxc", 3, "abc", 2},
+		{"max length before cursor", MaxLength(2), "abcdefghij", 5, "ab", 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, pos := c.tr([]rune(c.in), c.pos)
+			if string(out) != c.out || pos != c.outPos {
+				t.Errorf("wrong result: %s %d != %s %d", string(out), pos, c.out, c.outPos)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package promptui
+
+import (
+	"io"
+	"strings"
+
+	"github.com/karantin2020/readline"
+)
+
+// TreeNode is a node of TreeSelect
+type TreeNode struct {
+	// Label is the value displayed in the tree and returned in the path
+	Label string
+
+	// Children are the nested nodes
+	Children []*TreeNode
+
+	// HasChildren marks the node expandable when Children are not loaded yet,
+	// they are loaded with TreeSelect.LoadChildren on expand
+	HasChildren bool
+
+	// Expanded shows the node children
+	Expanded bool
+}
+
+// expandable reports if the node has or may have children
+func (n *TreeNode) expandable() bool {
+	return len(n.Children) > 0 || n.HasChildren
+}
+
+// treeRow is a visible node with its path from the root
+type treeRow struct {
+	path  []*TreeNode
+	guide string
+}
+
+func (r treeRow) node() *TreeNode {
+	return r.path[len(r.path)-1]
+}
+
+func (r treeRow) labels() []string {
+	out := make([]string, len(r.path))
+	for i, n := range r.path {
+		out[i] = n.Label
+	}
+	return out
+}
+
+// TreeSelect represents a hierarchical list for selecting a single node.
+// Right expands the node, Left collapses it or moves to the parent,
+// typing searches across all loaded levels.
+type TreeSelect struct {
+	BasicPrompt
+
+	// Nodes are the root nodes
+	Nodes []*TreeNode
+
+	// LoadChildren is optional. If set, it is called with the node path
+	// to load children of nodes with HasChildren set
+	LoadChildren func(path []string) ([]*TreeNode, error)
+
+	// LeavesOnly makes Enter expand and collapse nodes with children
+	// instead of choosing them
+	LeavesOnly bool
+
+	// Separator joins the path labels in the result line, "/" by default
+	Separator string
+
+	// Size is the max number of visible rows, 10 by default
+	Size int
+
+	rows     []treeRow
+	selected int
+	start    int
+	query    []rune
+	errMsg   string
+	screen   *screen
+}
+
+// Run runs the tree select. It returns the labels path of the chosen node
+// from the root.
+func (ts *TreeSelect) Run() ([]string, error) {
+	err := ts.Init()
+	if err != nil {
+		return nil, err
+	}
+	if ts.Size <= 0 {
+		ts.Size = 10
+	}
+	if ts.Separator == "" {
+		ts.Separator = "/"
+	}
+
+	ts.c.VimMode = false
+	ts.screen = &screen{w: ts.c.Stdout}
+	ts.query = ts.query[:0]
+	ts.update()
+	ts.c.Prompt = ts.footer()
+
+	ts.c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		if r == 0 {
+			// stdin is closed, readline handles io.EOF
+			return r, true
+		}
+		ts.errMsg = ""
+		if ts.toggleHelp(r, !ts.searching()) {
+			ts.rl.SetPrompt(ts.footer())
+			return r, false
+		}
+		r, ok := ts.KeyMap.resolve(r, ActionSubmit, ActionCancel, ActionNext, ActionPrev)
+		if !ok {
+			return r, false
+		}
+		switch r {
+		case readline.CharInterrupt:
+			return r, true
+		case readline.CharEnter:
+			if len(ts.rows) == 0 {
+				return r, false
+			}
+			row := ts.rows[ts.selected]
+			if !ts.LeavesOnly || !row.node().expandable() {
+				return r, true
+			}
+			if row.node().Expanded {
+				ts.collapse()
+			} else {
+				ts.expand()
+			}
+		case readline.CharPrev:
+			if ts.selected > 0 {
+				ts.selected--
+			}
+		case readline.CharNext:
+			if ts.selected < len(ts.rows)-1 {
+				ts.selected++
+			}
+		case readline.CharForward:
+			ts.expand()
+		case readline.CharBackward:
+			ts.collapse()
+		case readline.CharBackspace, readline.CharCtrlH:
+			if len(ts.query) > 0 {
+				ts.query = ts.query[:len(ts.query)-1]
+				ts.update()
+				ts.changed(string(ts.query))
+			}
+		default:
+			if !readline.IsPrintable(r) {
+				return r, false
+			}
+			ts.query = append(ts.query, r)
+			ts.update()
+			ts.changed(string(ts.query))
+		}
+		ts.draw()
+		ts.rl.SetPrompt(ts.footer())
+		return r, false
+	}
+
+	ts.rl, err = readline.NewEx(ts.c)
+	if err != nil {
+		return nil, err
+	}
+	defer ts.rl.Close()
+
+	ts.rl.Write([]byte(hideCursor))
+	ts.draw()
+	_, err = ts.rl.Readline()
+	ts.screen.clear()
+	ts.rl.Write([]byte(showCursor))
+
+	if err != nil {
+		switch {
+		case err == readline.ErrInterrupt, err.Error() == "Interrupt":
+			err = ErrInterrupt
+		case err == io.EOF:
+			err = ErrEOF
+		}
+		return nil, ts.canceled(err)
+	}
+
+	path := ts.rows[ts.selected].labels()
+	ts.out = ts.Formatter(strings.Join(path, ts.Separator))
+	ts.submitted(ts.out)
+	ts.state = ts.IconGood
+	ts.rl.Write([]byte(ts.Indent + ts.state + " " + ts.prompt + ts.InputResult(ts.out) + "\n"))
+	return path, nil
+}
+
+// expand shows children of the selected node, loading them if needed.
+// If the node is expanded already, the first child is selected
+func (ts *TreeSelect) expand() {
+	if ts.searching() || len(ts.rows) == 0 {
+		return
+	}
+	row := ts.rows[ts.selected]
+	n := row.node()
+	if !n.expandable() {
+		return
+	}
+	if n.Expanded {
+		if len(n.Children) > 0 {
+			ts.selected++
+		}
+		return
+	}
+	if len(n.Children) == 0 && ts.LoadChildren != nil {
+		children, err := ts.LoadChildren(row.labels())
+		if err != nil {
+			ts.errMsg = err.Error()
+			return
+		}
+		n.Children = children
+	}
+	n.Expanded = true
+	ts.update()
+}
+
+// collapse hides children of the selected node. If the node is collapsed
+// already, its parent is selected
+func (ts *TreeSelect) collapse() {
+	if ts.searching() || len(ts.rows) == 0 {
+		return
+	}
+	row := ts.rows[ts.selected]
+	if n := row.node(); n.Expanded {
+		n.Expanded = false
+		ts.update()
+		return
+	}
+	if len(row.path) < 2 {
+		return
+	}
+	parent := row.path[len(row.path)-2]
+	for i := ts.selected; i >= 0; i-- {
+		if ts.rows[i].node() == parent {
+			ts.selected = i
+			return
+		}
+	}
+}
+
+func (ts *TreeSelect) searching() bool {
+	return len(ts.query) > 0
+}
+
+// update rebuilds visible rows keeping the selected node
+func (ts *TreeSelect) update() {
+	var current *TreeNode
+	if ts.selected < len(ts.rows) {
+		current = ts.rows[ts.selected].node()
+	}
+	ts.rows = ts.rows[:0]
+	if ts.searching() {
+		ts.search(ts.Nodes, nil, strings.ToLower(string(ts.query)))
+	} else {
+		ts.flatten(ts.Nodes, nil, "")
+	}
+	ts.selected = 0
+	for i, r := range ts.rows {
+		if r.node() == current {
+			ts.selected = i
+		}
+	}
+}
+
+// flatten appends expanded nodes with indentation guides to rows
+func (ts *TreeSelect) flatten(nodes []*TreeNode, path []*TreeNode, indent string) {
+	for i, n := range nodes {
+		last := i == len(nodes)-1
+		connector, next := treeBranch, treeLine
+		if last {
+			connector, next = treeLast, "  "
+		}
+		marker := " "
+		if n.expandable() {
+			marker = treeCollapsed
+			if n.Expanded {
+				marker = treeExpanded
+			}
+		}
+		p := append(append([]*TreeNode{}, path...), n)
+		ts.rows = append(ts.rows, treeRow{path: p, guide: faint(indent+connector) + marker + " "})
+		if n.Expanded {
+			ts.flatten(n.Children, p, indent+next+" ")
+		}
+	}
+}
+
+// search appends loaded nodes matching the query on any level to rows
+func (ts *TreeSelect) search(nodes []*TreeNode, path []*TreeNode, query string) {
+	for _, n := range nodes {
+		p := append(append([]*TreeNode{}, path...), n)
+		if strings.Contains(strings.ToLower(n.Label), query) {
+			ts.rows = append(ts.rows, treeRow{path: p})
+		}
+		ts.search(n.Children, p, query)
+	}
+}
+
+// draw redraws the header and visible rows
+func (ts *TreeSelect) draw() {
+	if ts.selected < ts.start {
+		ts.start = ts.selected
+	}
+	if ts.selected >= ts.start+ts.Size {
+		ts.start = ts.selected - ts.Size + 1
+	}
+	if ts.start > len(ts.rows)-ts.Size {
+		ts.start = len(ts.rows) - ts.Size
+	}
+	if ts.start < 0 {
+		ts.start = 0
+	}
+	end := ts.start + ts.Size
+	if end > len(ts.rows) {
+		end = len(ts.rows)
+	}
+
+	header := ts.Indent + ts.IconInitial + " " + ts.PromptInitial(ts.prompt)
+	if ts.searching() {
+		header += cursorLine(ts.query, len(ts.query), ts.InputInitial)
+	}
+	lines := []string{header}
+	for i := ts.start; i < end; i++ {
+		row := ts.rows[i]
+		label := row.node().Label
+		if ts.searching() {
+			label = strings.Join(row.labels(), ts.Separator)
+		}
+		selection := " "
+		if i == ts.selected {
+			selection = IconQuest
+			label = blue(label)
+		}
+		lines = append(lines, ts.Indent+selection+" "+row.guide+label)
+	}
+	ts.screen.draw(lines)
+}
+
+// footer returns the line under the tree with load error or key hints.
+// All key bindings are listed if KeyHelp is set
+func (ts *TreeSelect) footer() string {
+	if ts.errMsg != "" {
+		return ts.Indent + "  " + red("Error: ") + ts.errMsg
+	}
+	bindings := []binding{
+		{keys: []string{"←", "→"}, desc: "collapse/expand"},
+		{keys: []string{"type"}, desc: "search"},
+	}
+	if ts.searching() {
+		bindings = []binding{
+			{keys: []string{"type"}, desc: "search"},
+			{keys: []string{"Backspace"}, desc: "clear"},
+		}
+	}
+	if ts.KeyHelp {
+		return ts.keyHelp(append(bindings,
+			binding{actions: []Action{ActionPrev, ActionNext}, desc: "move"},
+			binding{actions: []Action{ActionSubmit}, desc: "choose"},
+			binding{actions: []Action{ActionCancel}, desc: "cancel"})...)
+	}
+	return ts.Indent + "  " + faint(ts.KeyMap.help(bindings...))
+}
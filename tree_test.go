@@ -0,0 +1,90 @@
+package promptui
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTreeSelect(t *testing.T) {
+	loaded := 0
+	ts := TreeSelect{
+		Nodes: []*TreeNode{
+			{Label: "prod", HasChildren: true},
+			{Label: "dev", Children: []*TreeNode{{Label: "api"}}},
+		},
+		LoadChildren: func(path []string) ([]*TreeNode, error) {
+			loaded++
+			return []*TreeNode{{Label: path[0] + "-api"}, {Label: path[0] + "-web"}}, nil
+		},
+		Separator: "/",
+	}
+	ts.update()
+
+	labels := func() (out []string) {
+		for _, r := range ts.rows {
+			out = append(out, r.node().Label)
+		}
+		return
+	}
+
+	t.Run("lazy loads children on expand", func(t *testing.T) {
+		ts.expand()
+		ts.expand()
+		if loaded != 1 {
+			t.Errorf("children loaded %d times", loaded)
+		}
+		expected := []string{"prod", "prod-api", "prod-web", "dev"}
+		if got := labels(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("wrong rows: %v != %v", got, expected)
+		}
+		if ts.selected != 1 {
+			t.Errorf("first child is not selected: %d", ts.selected)
+		}
+	})
+
+	t.Run("draws indentation guides", func(t *testing.T) {
+		expected := faint(treeLine+" "+treeBranch) + "  "
+		if g := ts.rows[1].guide; g != expected {
+			t.Errorf("wrong guide: %q != %q", g, expected)
+		}
+	})
+
+	t.Run("collapse selects the parent", func(t *testing.T) {
+		ts.collapse()
+		if ts.selected != 0 {
+			t.Errorf("parent is not selected: %d", ts.selected)
+		}
+		ts.collapse()
+		expected := []string{"prod", "dev"}
+		if got := labels(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("wrong rows: %v != %v", got, expected)
+		}
+	})
+
+	t.Run("searches across levels", func(t *testing.T) {
+		ts.query = []rune("API")
+		ts.update()
+		var paths [][]string
+		for _, r := range ts.rows {
+			paths = append(paths, r.labels())
+		}
+		expected := [][]string{{"prod", "prod-api"}, {"dev", "api"}}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("wrong matches: %v != %v", paths, expected)
+		}
+	})
+}
+
+func TestTreeSelectEOF(t *testing.T) {
+	ts := TreeSelect{
+		BasicPrompt: BasicPrompt{
+			stdin:  &bytes.Buffer{},
+			stdout: &bytes.Buffer{},
+		},
+		Nodes: []*TreeNode{{Label: "prod"}},
+	}
+	if _, err := ts.Run(); err != ErrEOF {
+		t.Errorf("wrong error: %v != %v", err, ErrEOF)
+	}
+}